@@ -0,0 +1,11 @@
+// Package example shows how funkgen is used. The generated tests run with the rest of the tests,
+// and cmd/funkgen tests check the generated files are up to date.
+package example
+
+//go:generate go run github.com/bullgare/funktional/cmd/funkgen -o funk_gen.go -import time slices.Map[User,string]=UserNames slices.Filter[User] slices.Reduce[User,float64]=TotalBalance slices.FindIndex[*User] slices.Chunk[[]int] slices.Fill[time.Duration] slices.ReverseInPlace[string] maps.Map[User,string,int] maps.Filter[float64,string] maps.Keys[bool,int] maps.InvertGrouped[string,time.Weekday]
+
+// User is a record the specialized functions work with.
+type User struct {
+	Name    string
+	Balance float64
+}
@@ -0,0 +1,27 @@
+package example
+
+import (
+	"testing"
+
+	"github.com/bullgare/funktional/slices"
+)
+
+var benchUsers = func() []User {
+	users := make([]User, 1000)
+	for i := range users {
+		users[i] = User{Name: "user", Balance: float64(i)}
+	}
+	return users
+}()
+
+func Benchmark_Filter_Generic(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		slices.Filter(benchUsers, func(u User, _ int, _ []User) bool { return u.Balance > 500 })
+	}
+}
+
+func Benchmark_Filter_Specialized(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		SlicesFilterUser(benchUsers, func(u User, _ int, _ []User) bool { return u.Balance > 500 })
+	}
+}
@@ -0,0 +1,193 @@
+// Code generated by funkgen. DO NOT EDIT.
+
+package example
+
+import (
+	"time"
+)
+
+// UserNames is slices.Map[User, string] specialized by funkgen.
+func UserNames(in []User, convert func(User, int, []User) string) []string {
+	if in == nil {
+		return nil
+	}
+
+	res := make([]string, 0, len(in))
+
+	for i, elem := range in {
+		res = append(res, convert(elem, i, in))
+	}
+
+	return res
+}
+
+// SlicesFilterUser is slices.Filter[User] specialized by funkgen.
+func SlicesFilterUser(in []User, filter func(User, int, []User) bool) []User {
+	if in == nil {
+		return nil
+	}
+
+	res := make([]User, 0, len(in))
+
+	for i, elem := range in {
+		if filter(elem, i, in) {
+			res = append(res, elem)
+		}
+	}
+
+	return res
+}
+
+// TotalBalance is slices.Reduce[User, float64] specialized by funkgen.
+func TotalBalance(in []User, reduce func(float64, User, int) float64, acc float64) float64 {
+	if in == nil {
+		return acc
+	}
+
+	for i, elem := range in {
+		acc = reduce(acc, elem, i)
+	}
+
+	return acc
+}
+
+// SlicesFindIndexPtrUser is slices.FindIndex[*User] specialized by funkgen.
+func SlicesFindIndexPtrUser(in []*User, assertion func(*User) bool, fromIndex ...int) int {
+	startFrom := 0
+	if len(fromIndex) > 0 {
+		startFrom = fromIndex[0]
+	}
+	if startFrom >= len(in) {
+		return -1
+	}
+	if startFrom < 0 {
+		startFrom = 0
+	}
+
+	for i := startFrom; i < len(in); i++ {
+		if assertion(in[i]) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// SlicesChunkSliceInt is slices.Chunk[[]int] specialized by funkgen.
+func SlicesChunkSliceInt(in [][]int, size int) [][][]int {
+	if in == nil {
+		return nil
+	}
+
+	if size < 1 {
+		size = 1
+	}
+	res := make([][][]int, 0, 1+len(in)/size)
+	var current [][]int
+
+	for i := 0; i < len(in); i++ {
+		if (i % size) == 0 {
+			if current != nil {
+				res = append(res, current)
+			}
+			current = make([][]int, 0, size)
+		}
+		current = append(current, in[i])
+	}
+	res = append(res, current)
+
+	return res
+}
+
+// SlicesFillTimeDuration is slices.Fill[time.Duration] specialized by funkgen.
+func SlicesFillTimeDuration(in []time.Duration, value time.Duration, start, end int) []time.Duration {
+	if start > end || start > len(in) || end < 1 {
+		return in
+	}
+
+	if start < 0 {
+		start = 0
+	}
+	if end > len(in) {
+		end = len(in)
+	}
+
+	for i := start; i < end; i++ {
+		in[i] = value
+	}
+
+	return in
+}
+
+// SlicesReverseInPlaceString is slices.ReverseInPlace[string] specialized by funkgen.
+func SlicesReverseInPlaceString(in []string) {
+	if in == nil {
+		return
+	}
+
+	l := len(in)
+	for i := 0; i < l/2; i++ {
+		in[i], in[l-1-i] = in[l-1-i], in[i]
+	}
+}
+
+// MapsMapUserStringInt is maps.Map[User, string, int] specialized by funkgen.
+func MapsMapUserStringInt(in map[int]User, convert func(User, int, map[int]User) string) map[int]string {
+	if in == nil {
+		return nil
+	}
+
+	out := make(map[int]string, len(in))
+	for k, v := range in {
+		out[k] = convert(v, k, in)
+	}
+
+	return out
+}
+
+// MapsFilterFloat64String is maps.Filter[float64, string] specialized by funkgen.
+func MapsFilterFloat64String(in map[string]float64, filter func(float64, string, map[string]float64) bool) map[string]float64 {
+	if in == nil {
+		return nil
+	}
+
+	res := make(map[string]float64, len(in))
+
+	for k, v := range in {
+		if filter(v, k, in) {
+			res[k] = v
+		}
+	}
+
+	return res
+}
+
+// MapsKeysBoolInt is maps.Keys[bool, int] specialized by funkgen.
+func MapsKeysBoolInt(in map[int]bool) []int {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]int, 0, len(in))
+	for k := range in {
+		out = append(out, k)
+	}
+	return out
+}
+
+// MapsInvertGroupedStringTimeWeekday is maps.InvertGrouped[string, time.Weekday] specialized by funkgen.
+func MapsInvertGroupedStringTimeWeekday(in map[string]time.Weekday) map[time.Weekday][]string {
+	if in == nil {
+		return nil
+	}
+
+	out := make(map[time.Weekday][]string, len(in))
+	for k, v := range in {
+		prev := out[v]
+		if prev == nil {
+			prev = make([]string, 0, 1)
+		}
+		out[v] = append(prev, k)
+	}
+	return out
+}
@@ -0,0 +1,56 @@
+// Code generated by funkgen. DO NOT EDIT.
+
+package example
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bullgare/funktional/funkgentest"
+	"github.com/bullgare/funktional/maps"
+	"github.com/bullgare/funktional/slices"
+)
+
+func Test_UserNames(t *testing.T) {
+	funkgentest.Equivalent(t, slices.Map[User, string], UserNames)
+}
+
+func Test_SlicesFilterUser(t *testing.T) {
+	funkgentest.Equivalent(t, slices.Filter[User], SlicesFilterUser)
+}
+
+func Test_TotalBalance(t *testing.T) {
+	funkgentest.Equivalent(t, slices.Reduce[User, float64], TotalBalance)
+}
+
+func Test_SlicesFindIndexPtrUser(t *testing.T) {
+	funkgentest.Equivalent(t, slices.FindIndex[*User], SlicesFindIndexPtrUser)
+}
+
+func Test_SlicesChunkSliceInt(t *testing.T) {
+	funkgentest.Equivalent(t, slices.Chunk[[]int], SlicesChunkSliceInt)
+}
+
+func Test_SlicesFillTimeDuration(t *testing.T) {
+	funkgentest.Equivalent(t, slices.Fill[time.Duration], SlicesFillTimeDuration)
+}
+
+func Test_SlicesReverseInPlaceString(t *testing.T) {
+	funkgentest.Equivalent(t, slices.ReverseInPlace[string], SlicesReverseInPlaceString)
+}
+
+func Test_MapsMapUserStringInt(t *testing.T) {
+	funkgentest.Equivalent(t, maps.Map[User, string, int], MapsMapUserStringInt)
+}
+
+func Test_MapsFilterFloat64String(t *testing.T) {
+	funkgentest.Equivalent(t, maps.Filter[float64, string], MapsFilterFloat64String)
+}
+
+func Test_MapsKeysBoolInt(t *testing.T) {
+	funkgentest.Equivalent(t, maps.Keys[bool, int], MapsKeysBoolInt)
+}
+
+func Test_MapsInvertGroupedStringTimeWeekday(t *testing.T) {
+	funkgentest.Equivalent(t, maps.InvertGrouped[string, time.Weekday], MapsInvertGroupedStringTimeWeekday)
+}
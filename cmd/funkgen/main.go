@@ -0,0 +1,515 @@
+// Command funkgen generates type-specialized (non-generic) copies of funktional functions,
+// which the compiler can inline and optimize better in hot paths, together with tests proving
+// they behave exactly like the generic versions.
+//
+// Functions are selected as instantiations, a name can be set after "=":
+//
+//	//go:generate go run github.com/bullgare/funktional/cmd/funkgen -o funk_gen.go slices.Map[User,string]=UserNames maps.Keys[int,string]
+//
+// Without a name, it is made of the package, the function and the type arguments, like SlicesMapUserString.
+// Types from other packages need their import paths passed with -import.
+// Only functions which do not depend on other functions of their package can be specialized.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const modulePath = "github.com/bullgare/funktional"
+
+// packages can be specialized.
+var packages = map[string]string{
+	"slices": modulePath + "/slices",
+	"maps":   modulePath + "/maps",
+}
+
+var errUsage = errors.New("usage: funkgen [-o file] [-pkg name] [-import path]... [-tests=false] pkg.Func[Type,...][=Name]...")
+
+type importsFlag []string
+
+func (f *importsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *importsFlag) Set(v string) error {
+	*f = append(*f, v)
+	return nil
+}
+
+type config struct {
+	pkg     string
+	imports []string
+	specs   []string
+	// sourceDir returns the directory of a package
+	sourceDir func(importPath string) (string, error)
+}
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "funkgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	fs := flag.NewFlagSet("funkgen", flag.ContinueOnError)
+	out := fs.String("o", "funkgen_gen.go", "output file, tests go to the file with the _test suffix")
+	pkg := fs.String("pkg", os.Getenv("GOPACKAGE"), "package name, $GOPACKAGE (set by go generate) by default")
+	tests := fs.Bool("tests", true, "generate tests comparing specialized functions with the generic ones")
+	var imports importsFlag
+	fs.Var(&imports, "import", "import path of a package used in type arguments, as path or name=path (repeatable)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *pkg == "" || fs.NArg() == 0 {
+		return errUsage
+	}
+
+	code, test, err := generate(config{pkg: *pkg, imports: imports, specs: fs.Args(), sourceDir: goListDir})
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(*out, code, 0o644); err != nil {
+		return err
+	}
+	if *tests {
+		return os.WriteFile(strings.TrimSuffix(*out, ".go")+"_test.go", test, 0o644)
+	}
+	return nil
+}
+
+func goListDir(importPath string) (string, error) {
+	out, err := exec.Command("go", "list", "-f", "{{.Dir}}", importPath).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", fmt.Errorf("go list %s: %s", importPath, bytes.TrimSpace(exitErr.Stderr))
+		}
+		return "", fmt.Errorf("go list %s: %w", importPath, err)
+	}
+	return string(bytes.TrimSpace(out)), nil
+}
+
+// spec is a function to specialize.
+type spec struct {
+	pkg      string
+	fn       string
+	typeArgs []ast.Expr
+	name     string
+}
+
+func parseSpec(s string) (spec, error) {
+	expr, name, _ := strings.Cut(s, "=")
+
+	e, err := parser.ParseExpr(expr)
+	if err != nil {
+		return spec{}, fmt.Errorf("%s: %w", s, err)
+	}
+
+	var (
+		fn       ast.Expr
+		typeArgs []ast.Expr
+	)
+	switch e := e.(type) {
+	case *ast.IndexExpr:
+		fn, typeArgs = e.X, []ast.Expr{e.Index}
+	case *ast.IndexListExpr:
+		fn, typeArgs = e.X, e.Indices
+	default:
+		return spec{}, fmt.Errorf("%s: expected an instantiation like slices.Map[int,string]", s)
+	}
+
+	sel, ok := fn.(*ast.SelectorExpr)
+	if !ok {
+		return spec{}, fmt.Errorf("%s: expected a function like slices.Map", s)
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	if !ok || packages[pkg.Name] == "" {
+		return spec{}, fmt.Errorf("%s: unknown package, expected one of slices, maps", s)
+	}
+
+	res := spec{pkg: pkg.Name, fn: sel.Sel.Name, typeArgs: typeArgs, name: name}
+	if res.name == "" {
+		res.name = upperFirst(res.pkg) + res.fn
+		for _, arg := range typeArgs {
+			n, err := typeName(arg)
+			if err != nil {
+				return spec{}, fmt.Errorf("%s: %w, set a name with =Name", s, err)
+			}
+			res.name += n
+		}
+	}
+	if !token.IsIdentifier(res.name) {
+		return spec{}, fmt.Errorf("%s: %q is not a valid name", s, res.name)
+	}
+
+	return res, nil
+}
+
+// typeName makes a part of a function name out of a type.
+func typeName(e ast.Expr) (string, error) {
+	switch e := e.(type) {
+	case *ast.Ident:
+		return upperFirst(e.Name), nil
+	case *ast.SelectorExpr:
+		return joinTypeNames("", e.X, e.Sel)
+	case *ast.StarExpr:
+		return joinTypeNames("Ptr", e.X)
+	case *ast.ArrayType:
+		if e.Len == nil {
+			return joinTypeNames("Slice", e.Elt)
+		}
+	case *ast.MapType:
+		return joinTypeNames("Map", e.Key, e.Value)
+	}
+	return "", fmt.Errorf("cannot make a name out of %s", exprString(e))
+}
+
+func joinTypeNames(prefix string, parts ...ast.Expr) (string, error) {
+	res := prefix
+	for _, p := range parts {
+		n, err := typeName(p)
+		if err != nil {
+			return "", err
+		}
+		res += n
+	}
+	return res, nil
+}
+
+func upperFirst(s string) string {
+	r := []rune(s)
+	if len(r) > 0 {
+		r[0] = unicode.ToUpper(r[0])
+	}
+	return string(r)
+}
+
+func exprString(e ast.Expr) string {
+	var buf bytes.Buffer
+	_ = printer.Fprint(&buf, token.NewFileSet(), e)
+	return buf.String()
+}
+
+// typeArgString prints a type so it can be converted to, like (*User)(x).
+func typeArgString(e ast.Expr) string {
+	switch e.(type) {
+	case *ast.StarExpr, *ast.FuncType, *ast.ChanType:
+		return "(" + exprString(e) + ")"
+	default:
+		return exprString(e)
+	}
+}
+
+// source is a parsed package.
+type source struct {
+	fset  *token.FileSet
+	files []*ast.File
+	// names are declared on the package level
+	names map[string]bool
+}
+
+func loadSource(dir string) (*source, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	src := &source{fset: token.NewFileSet(), names: map[string]bool{}}
+	for _, p := range paths {
+		if strings.HasSuffix(p, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(src.fset, p, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		src.files = append(src.files, f)
+		for name := range f.Scope.Objects {
+			src.names[name] = true
+		}
+	}
+
+	return src, nil
+}
+
+// specialized is a generic function with type parameters replaced by the type arguments.
+type specialized struct {
+	fset *token.FileSet
+	decl *ast.FuncDecl
+}
+
+// specialize returns a copy of a generic function with type parameters replaced by the type arguments.
+func (src *source) specialize(s spec) (*specialized, error) {
+	for _, f := range src.files {
+		for _, decl := range f.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Recv != nil || fd.Name.Name != s.fn {
+				continue
+			}
+			return src.specializeDecl(f, fd, s)
+		}
+	}
+	return nil, fmt.Errorf("%s.%s: function not found", s.pkg, s.fn)
+}
+
+func (src *source) specializeDecl(f *ast.File, fd *ast.FuncDecl, s spec) (*specialized, error) {
+	// the file is parsed again, so the original declaration stays untouched for other specs
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src.printNode(fd), 0)
+	if err != nil {
+		return nil, err
+	}
+	fd = file.Decls[0].(*ast.FuncDecl)
+
+	if fd.Type.TypeParams == nil {
+		return nil, fmt.Errorf("%s.%s: function is not generic", s.pkg, s.fn)
+	}
+	var params []*ast.Ident
+	for _, field := range fd.Type.TypeParams.List {
+		params = append(params, field.Names...)
+	}
+	if len(params) != len(s.typeArgs) {
+		return nil, fmt.Errorf("%s.%s: expected %d type arguments, got %d", s.pkg, s.fn, len(params), len(s.typeArgs))
+	}
+
+	if err := src.checkDependencies(f, fd, s); err != nil {
+		return nil, err
+	}
+
+	replacements := make(map[*ast.Object]ast.Expr, len(params))
+	for i, p := range params {
+		replacements[p.Obj] = s.typeArgs[i]
+	}
+	// conversions like T(x) need parentheses for types like *User
+	conversions := map[*ast.Ident]bool{}
+	ast.Inspect(fd, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			if id, ok := n.Fun.(*ast.Ident); ok {
+				conversions[id] = true
+			}
+		case *ast.Ident:
+			if typeArg, ok := replacements[n.Obj]; ok && n.Obj != nil {
+				n.Name = exprString(typeArg)
+				if conversions[n] {
+					n.Name = typeArgString(typeArg)
+				}
+			}
+		}
+		return true
+	})
+
+	fd.Type.TypeParams = nil
+	fd.Name.Name = s.name
+
+	return &specialized{fset: fset, decl: fd}, nil
+}
+
+// printNode prints a declaration as a source file.
+func (src *source) printNode(fd *ast.FuncDecl) string {
+	var buf bytes.Buffer
+	buf.WriteString("package p\n\n")
+	_ = printer.Fprint(&buf, src.fset, &ast.FuncDecl{Name: fd.Name, Type: fd.Type, Body: fd.Body})
+	return buf.String()
+}
+
+// checkDependencies makes sure a function uses nothing but builtins and its own parameters.
+func (src *source) checkDependencies(f *ast.File, fd *ast.FuncDecl, s spec) error {
+	imports := map[string]bool{}
+	for _, imp := range f.Imports {
+		name := path.Base(strings.Trim(imp.Path.Value, `"`))
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		imports[name] = true
+	}
+
+	var (
+		err   error
+		check func(ast.Node) bool
+	)
+	check = func(n ast.Node) bool {
+		if err != nil {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			if x, ok := n.X.(*ast.Ident); ok && x.Obj == nil && imports[x.Name] {
+				err = fmt.Errorf("%s.%s: cannot be specialized, it depends on package %s", s.pkg, s.fn, x.Name)
+				return false
+			}
+			// a selected field or method is not a package level name
+			ast.Inspect(n.X, check)
+			return false
+		case *ast.Ident:
+			if n.Obj == nil && src.names[n.Name] {
+				err = fmt.Errorf("%s.%s: cannot be specialized, it depends on %s", s.pkg, s.fn, n.Name)
+			}
+		}
+		return true
+	}
+	ast.Inspect(fd.Body, check)
+
+	return err
+}
+
+func typeArgsString(args []ast.Expr) string {
+	parts := make([]string, 0, len(args))
+	for _, a := range args {
+		parts = append(parts, exprString(a))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// generate returns the code of specialized functions and their tests.
+func generate(cfg config) ([]byte, []byte, error) {
+	typeImports := map[string]string{}
+	for _, imp := range cfg.imports {
+		name, p, ok := strings.Cut(imp, "=")
+		if !ok {
+			name, p = path.Base(imp), imp
+		}
+		typeImports[name] = p
+	}
+
+	sources := map[string]*source{}
+	var (
+		code, tests   bytes.Buffer
+		usedImports   = map[string]bool{}
+		usedPackages  = map[string]bool{}
+		names         = map[string]bool{}
+		typeArgImport = func(e ast.Expr) error {
+			var err error
+			ast.Inspect(e, func(n ast.Node) bool {
+				if sel, ok := n.(*ast.SelectorExpr); ok {
+					x, _ := sel.X.(*ast.Ident)
+					if x == nil || typeImports[x.Name] == "" {
+						err = fmt.Errorf("type %s: unknown package, pass its import path with -import", exprString(sel))
+						return false
+					}
+					usedImports[typeImports[x.Name]+"\x00"+x.Name] = true
+					return false
+				}
+				return true
+			})
+			return err
+		}
+	)
+
+	for _, raw := range cfg.specs {
+		s, err := parseSpec(raw)
+		if err != nil {
+			return nil, nil, err
+		}
+		if names[s.name] {
+			return nil, nil, fmt.Errorf("%s: duplicate name %s", raw, s.name)
+		}
+		names[s.name] = true
+
+		for _, arg := range s.typeArgs {
+			if err := typeArgImport(arg); err != nil {
+				return nil, nil, fmt.Errorf("%s: %w", raw, err)
+			}
+		}
+
+		src, ok := sources[s.pkg]
+		if !ok {
+			dir, err := cfg.sourceDir(packages[s.pkg])
+			if err != nil {
+				return nil, nil, err
+			}
+			if src, err = loadSource(dir); err != nil {
+				return nil, nil, err
+			}
+			sources[s.pkg] = src
+		}
+
+		fn, err := src.specialize(s)
+		if err != nil {
+			return nil, nil, err
+		}
+		fmt.Fprintf(&code, "\n// %s is %s.%s[%s] specialized by funkgen.\n", s.name, s.pkg, s.fn, typeArgsString(s.typeArgs))
+		if err := printer.Fprint(&code, fn.fset, fn.decl); err != nil {
+			return nil, nil, err
+		}
+		code.WriteString("\n")
+
+		usedPackages[s.pkg] = true
+		fmt.Fprintf(&tests, "\nfunc Test_%s(t *testing.T) {\n\tfunkgentest.Equivalent(t, %s.%s[%s], %s)\n}\n",
+			s.name, s.pkg, s.fn, typeArgsString(s.typeArgs), s.name)
+	}
+
+	var typeImportLines []string
+	for imp := range usedImports {
+		p, name, _ := strings.Cut(imp, "\x00")
+		line := fmt.Sprintf("%q", p)
+		if path.Base(p) != name {
+			line = name + " " + line
+		}
+		typeImportLines = append(typeImportLines, line)
+	}
+
+	testImports := []string{`"testing"`, fmt.Sprintf("%q", modulePath+"/funkgentest")}
+	for pkg := range usedPackages {
+		testImports = append(testImports, fmt.Sprintf("%q", packages[pkg]))
+	}
+
+	codeFile, err := file(cfg.pkg, typeImportLines, code.Bytes())
+	if err != nil {
+		return nil, nil, err
+	}
+	testFile, err := file(cfg.pkg, append(testImports, typeImportLines...), tests.Bytes())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return codeFile, testFile, nil
+}
+
+func file(pkg string, imports []string, body []byte) ([]byte, error) {
+	// standard packages go first, in a group of their own
+	var std, other []string
+	for _, imp := range imports {
+		p := imp[strings.Index(imp, `"`)+1:]
+		if first, _, _ := strings.Cut(p, "/"); strings.Contains(first, ".") {
+			other = append(other, imp)
+		} else {
+			std = append(std, imp)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(other)
+	groups := make([]string, 0, 2)
+	for _, g := range [][]string{std, other} {
+		if len(g) > 0 {
+			groups = append(groups, strings.Join(g, "\n\t"))
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by funkgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n", pkg)
+	if len(groups) > 0 {
+		fmt.Fprintf(&buf, "\nimport (\n\t%s\n)\n", strings.Join(groups, "\n\n\t"))
+	}
+	buf.Write(body)
+
+	return format.Source(buf.Bytes())
+}
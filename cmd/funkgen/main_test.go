@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// localDir finds packages of this module without calling go list.
+func localDir(importPath string) (string, error) {
+	return filepath.Join("..", "..", strings.TrimPrefix(importPath, modulePath+"/")), nil
+}
+
+func Test_Generate_ExampleIsUpToDate(t *testing.T) {
+	dir := filepath.Join("internal", "example")
+	src, err := os.ReadFile(filepath.Join(dir, "example.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var args []string
+	for _, line := range strings.Split(string(src), "\n") {
+		if cmd, ok := strings.CutPrefix(line, "//go:generate go run "+modulePath+"/cmd/funkgen "); ok {
+			args = strings.Fields(cmd)
+		}
+	}
+	if len(args) < 2 || args[0] != "-o" || args[2] != "-import" {
+		t.Fatalf("unexpected go:generate directive %v", args)
+	}
+
+	code, test, err := generate(config{pkg: "example", imports: []string{args[3]}, specs: args[4:], sourceDir: localDir})
+	if err != nil {
+		t.Fatalf("generate: unexpected error %v", err)
+	}
+
+	for name, generated := range map[string][]byte{args[1]: code, strings.TrimSuffix(args[1], ".go") + "_test.go": test} {
+		committed, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(committed, generated) {
+			t.Fatalf("%s is out of date, run go generate ./cmd/funkgen/...\n%s", name, generated)
+		}
+	}
+}
+
+func Test_Generate(t *testing.T) {
+	code, test, err := generate(config{
+		pkg:       "hot",
+		imports:   []string{"tm=time"},
+		specs:     []string{"maps.Map[tm.Duration,map[string]int,string]", "slices.ReverseInPlace[*int]"},
+		sourceDir: localDir,
+	})
+	if err != nil {
+		t.Fatalf("generate: unexpected error %v", err)
+	}
+
+	for _, expected := range []string{
+		"package hot\n",
+		`tm "time"`,
+		"// MapsMapTmDurationMapStringIntString is maps.Map[tm.Duration, map[string]int, string] specialized by funkgen.\n",
+		"func MapsMapTmDurationMapStringIntString(in map[string]tm.Duration, convert func(tm.Duration, string, map[string]tm.Duration) map[string]int) map[string]map[string]int {",
+		"func SlicesReverseInPlacePtrInt(in []*int) {",
+	} {
+		if !strings.Contains(string(code), expected) {
+			t.Fatalf("generate: expected %q in\n%s", expected, code)
+		}
+	}
+
+	expected := "funkgentest.Equivalent(t, maps.Map[tm.Duration, map[string]int, string], MapsMapTmDurationMapStringIntString)"
+	if !strings.Contains(string(test), expected) || !strings.Contains(string(test), `"github.com/bullgare/funktional/slices"`) {
+		t.Fatalf("generate: unexpected tests\n%s", test)
+	}
+}
+
+func Test_Generate_Errors(t *testing.T) {
+	tt := []struct {
+		name  string
+		specs []string
+		err   string
+	}{
+		{name: "not an instantiation", specs: []string{"slices.Map"}, err: "expected an instantiation"},
+		{name: "unknown package", specs: []string{"sort.Slice[int]"}, err: "unknown package"},
+		{name: "unknown function", specs: []string{"slices.Nope[int]"}, err: "function not found"},
+		{name: "not generic", specs: []string{"maps.Flatten[int]"}, err: "not generic"},
+		{name: "wrong number of type arguments", specs: []string{"slices.Map[int]"}, err: "expected 2 type arguments, got 1"},
+		{name: "depends on a function", specs: []string{"slices.InnerJoin[int,int,int,int]"}, err: "depends on indexByKey"},
+		{name: "depends on a package", specs: []string{"slices.DeepCopy[int]"}, err: "depends on package deepcopy"},
+		{name: "unknown type package", specs: []string{"slices.Copy[time.Duration]"}, err: "pass its import path with -import"},
+		{name: "no name for a type", specs: []string{"slices.Copy[func()]"}, err: "set a name with =Name"},
+		{name: "invalid name", specs: []string{"slices.Copy[int]=a-b"}, err: "not a valid name"},
+		{name: "duplicate name", specs: []string{"slices.Copy[int]=A", "maps.Copy[int,int]=A"}, err: "duplicate name A"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := generate(config{pkg: "p", specs: tc.specs, sourceDir: localDir})
+
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("generate %s: expected error %q, got %v", tc.name, tc.err, err)
+			}
+		})
+	}
+}
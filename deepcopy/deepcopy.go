@@ -0,0 +1,142 @@
+// Package deepcopy implements deep copying of arbitrary values via reflection.
+// It is used by slices.DeepCopy and maps.DeepCopy.
+package deepcopy
+
+import (
+	"reflect"
+)
+
+// Cloner can be implemented by types which need to control their own copying.
+// Clone must return a copy of the same type as the receiver, and it is called instead of copying the value via reflection.
+type Cloner[T any] interface {
+	Clone() T
+}
+
+// Copy creates a deep copy of a value.
+// It follows pointers, slices, arrays, maps, interfaces and exported struct fields.
+// Unexported struct fields, channels, functions and map keys are copied shallowly.
+// Cycles and shared references are preserved: a pointer (map, slice) seen twice is copied once.
+func Copy[T any](in T) T {
+	c := copier{visited: make(map[visitKey]reflect.Value)}
+
+	var out T
+	v := reflect.ValueOf(&in).Elem()
+	reflect.ValueOf(&out).Elem().Set(c.copy(v))
+
+	return out
+}
+
+type visitKey struct {
+	typ reflect.Type
+	ptr uintptr
+	len int
+}
+
+type copier struct {
+	visited map[visitKey]reflect.Value
+}
+
+func (c copier) copy(v reflect.Value) reflect.Value {
+	if out, ok := c.clone(v); ok {
+		return out
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		key := visitKey{typ: v.Type(), ptr: v.Pointer()}
+		if out, ok := c.visited[key]; ok {
+			return out
+		}
+		out := reflect.New(v.Type().Elem())
+		c.visited[key] = out
+		out.Elem().Set(c.copy(v.Elem()))
+		return out
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type()).Elem()
+		out.Set(c.copy(v.Elem()))
+		return out
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		key := visitKey{typ: v.Type(), ptr: v.Pointer(), len: v.Len()}
+		if out, ok := c.visited[key]; ok {
+			return out
+		}
+		out := reflect.MakeSlice(v.Type(), v.Len(), v.Cap())
+		c.visited[key] = out
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(c.copy(v.Index(i)))
+		}
+		return out
+
+	case reflect.Array:
+		out := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(c.copy(v.Index(i)))
+		}
+		return out
+
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		key := visitKey{typ: v.Type(), ptr: v.Pointer()}
+		if out, ok := c.visited[key]; ok {
+			return out
+		}
+		out := reflect.MakeMapWithSize(v.Type(), v.Len())
+		c.visited[key] = out
+		iter := v.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(iter.Key(), c.copy(iter.Value()))
+		}
+		return out
+
+	case reflect.Struct:
+		out := reflect.New(v.Type()).Elem()
+		// unexported fields are copied as they are
+		out.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if out.Field(i).CanSet() {
+				out.Field(i).Set(c.copy(v.Field(i)))
+			}
+		}
+		return out
+
+	default:
+		return v
+	}
+}
+
+// clone calls Clone method if v implements Cloner of its own type.
+func (c copier) clone(v reflect.Value) (reflect.Value, bool) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice:
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+	case reflect.Interface, reflect.Invalid:
+		// the dynamic value is checked on its own
+		return reflect.Value{}, false
+	}
+
+	m, ok := v.Type().MethodByName("Clone")
+	if !ok || m.PkgPath != "" {
+		return reflect.Value{}, false
+	}
+	// the method value of a type includes the receiver as the first argument
+	if m.Type.NumIn() != 1 || m.Type.NumOut() != 1 || m.Type.Out(0) != v.Type() {
+		return reflect.Value{}, false
+	}
+
+	return m.Func.Call([]reflect.Value{v})[0], true
+}
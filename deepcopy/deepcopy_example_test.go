@@ -0,0 +1,21 @@
+package deepcopy
+
+import "fmt"
+
+type user struct {
+	Name string
+	Tags []string
+}
+
+func ExampleCopy() {
+	in := []*user{{Name: "a", Tags: []string{"x"}}}
+
+	out := Copy(in)
+	in[0].Name = "b"
+	in[0].Tags[0] = "y"
+
+	fmt.Println(out[0].Name, out[0].Tags)
+
+	// Output:
+	// a [x]
+}
@@ -0,0 +1,127 @@
+package deepcopy
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type node struct {
+	Name     string
+	Children []*node
+	Parent   *node
+	Meta     map[string]any
+	hidden   []int
+}
+
+type counter struct {
+	N      int
+	copies *int
+}
+
+func (c counter) Clone() counter {
+	*c.copies++
+	return counter{N: c.N * 10, copies: c.copies}
+}
+
+type ptrCloner struct {
+	Items []int
+}
+
+func (p *ptrCloner) Clone() *ptrCloner {
+	return &ptrCloner{Items: []int{-1}}
+}
+
+func Test_Copy(t *testing.T) {
+	tt := []struct {
+		name string
+		in   any
+	}{
+		{name: "nil", in: nil},
+		{name: "int", in: 42},
+		{name: "slice of slices", in: [][]int{{1, 2}, {3}, nil}},
+		{name: "map of slices", in: map[string][]string{"a": {"x"}, "b": nil}},
+		{name: "array of pointers", in: [2]*int{new(int), nil}},
+		{name: "struct", in: node{Name: "a", Children: []*node{{Name: "b"}}, Meta: map[string]any{"x": []any{1, "2"}}}},
+		{name: "time", in: time.Date(2020, 1, 2, 3, 4, 5, 6, time.UTC)},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			res := Copy(tc.in)
+
+			if !reflect.DeepEqual(res, tc.in) {
+				t.Fatalf(`Copy %s: expected
+				%#v, got
+				%#v`, tc.name, tc.in, res)
+			}
+		})
+	}
+}
+
+func Test_Copy_IsDeep(t *testing.T) {
+	in := map[string][]*node{
+		"a": {{Name: "a", Meta: map[string]any{"k": []int{1}}, hidden: []int{1}}},
+	}
+
+	res := Copy(in)
+	in["a"][0].Name = "changed"
+	in["a"][0].Meta["k"].([]int)[0] = 2
+	in["a"][0].hidden[0] = 2
+	in["a"] = append(in["a"], &node{})
+
+	if len(res["a"]) != 1 || res["a"][0].Name != "a" || res["a"][0].Meta["k"].([]int)[0] != 1 {
+		t.Fatalf("Copy: copy was changed with the original: %#v", res["a"][0])
+	}
+	// unexported fields are copied shallowly
+	if res["a"][0].hidden[0] != 2 {
+		t.Fatalf("Copy: unexpected unexported field %#v", res["a"][0].hidden)
+	}
+}
+
+func Test_Copy_Cycles(t *testing.T) {
+	root := &node{Name: "root"}
+	child := &node{Name: "child", Parent: root}
+	root.Children = []*node{child, child}
+
+	res := Copy(root)
+
+	if res == root || res.Children[0] == child {
+		t.Fatal("Copy: pointers were not copied")
+	}
+	if res.Children[0].Parent != res {
+		t.Fatal("Copy: cycle was not preserved")
+	}
+	if res.Children[0] != res.Children[1] {
+		t.Fatal("Copy: shared pointer was copied twice")
+	}
+
+	m := map[string]any{}
+	m["self"] = m
+	resMap := Copy(m)
+	if reflect.ValueOf(resMap["self"]).Pointer() != reflect.ValueOf(resMap).Pointer() {
+		t.Fatal("Copy: map cycle was not preserved")
+	}
+}
+
+func Test_Copy_Cloner(t *testing.T) {
+	copies := 0
+	in := []any{counter{N: 1, copies: &copies}, &ptrCloner{Items: []int{1}}}
+
+	res := Copy(in)
+
+	if copies != 1 {
+		t.Fatalf("Copy: expected Clone to be called once, got %d", copies)
+	}
+	if res[0].(counter).N != 10 {
+		t.Fatalf("Copy: value Cloner was not used: %#v", res[0])
+	}
+	if !reflect.DeepEqual(res[1].(*ptrCloner).Items, []int{-1}) {
+		t.Fatalf("Copy: pointer Cloner was not used: %#v", res[1])
+	}
+
+	var nilCloner *ptrCloner
+	if Copy(nilCloner) != nil {
+		t.Fatal("Copy: Clone should not be called on nil")
+	}
+}
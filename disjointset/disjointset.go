@@ -0,0 +1,130 @@
+// Package disjointset implements a union-find structure to group elements linked transitively.
+package disjointset
+
+// DisjointSet keeps elements split into non-overlapping sets.
+// It uses path compression and union by rank, so all the operations take almost constant amortized time.
+// The zero value is an empty set ready to use. It is not safe for concurrent use.
+type DisjointSet[K comparable] struct {
+	index map[K]int
+	// items, parents and ranks are indexed by the order of addition
+	items   []K
+	parents []int
+	ranks   []uint8
+	sets    int
+}
+
+// New creates a disjoint set with every given element in a set of its own.
+func New[K comparable](items ...K) *DisjointSet[K] {
+	d := &DisjointSet[K]{index: make(map[K]int, len(items))}
+	for _, item := range items {
+		d.Add(item)
+	}
+	return d
+}
+
+// Len returns the number of elements.
+func (d *DisjointSet[K]) Len() int {
+	return len(d.items)
+}
+
+// Count returns the number of sets.
+func (d *DisjointSet[K]) Count() int {
+	return d.sets
+}
+
+// Has reports whether an element was added.
+func (d *DisjointSet[K]) Has(item K) bool {
+	_, ok := d.index[item]
+	return ok
+}
+
+// Add puts an element into a set of its own. It returns false if the element is already there.
+func (d *DisjointSet[K]) Add(item K) bool {
+	if d.Has(item) {
+		return false
+	}
+	d.add(item)
+	return true
+}
+
+func (d *DisjointSet[K]) add(item K) int {
+	if d.index == nil {
+		d.index = make(map[K]int)
+	}
+
+	i := len(d.items)
+	d.index[item] = i
+	d.items = append(d.items, item)
+	d.parents = append(d.parents, i)
+	d.ranks = append(d.ranks, 0)
+	d.sets++
+
+	return i
+}
+
+// Find returns the representative of the set an element belongs to.
+// Elements of the same set have the same representative until the next Union.
+// An element which was not added is the representative of itself.
+func (d *DisjointSet[K]) Find(item K) K {
+	i, ok := d.index[item]
+	if !ok {
+		return item
+	}
+	return d.items[d.root(i)]
+}
+
+func (d *DisjointSet[K]) root(i int) int {
+	r := i
+	for d.parents[r] != r {
+		r = d.parents[r]
+	}
+	for d.parents[i] != r {
+		d.parents[i], i = r, d.parents[i]
+	}
+	return r
+}
+
+// Union merges the sets of two elements, adding the elements if needed.
+// It returns false if they are already in the same set.
+func (d *DisjointSet[K]) Union(a, b K) bool {
+	ia, ok := d.index[a]
+	if !ok {
+		ia = d.add(a)
+	}
+	ib, ok := d.index[b]
+	if !ok {
+		ib = d.add(b)
+	}
+
+	ra, rb := d.root(ia), d.root(ib)
+	if ra == rb {
+		return false
+	}
+
+	if d.ranks[ra] < d.ranks[rb] {
+		ra, rb = rb, ra
+	}
+	d.parents[rb] = ra
+	if d.ranks[ra] == d.ranks[rb] {
+		d.ranks[ra]++
+	}
+	d.sets--
+
+	return true
+}
+
+// Connected reports whether two elements are in the same set.
+func (d *DisjointSet[K]) Connected(a, b K) bool {
+	return d.Find(a) == d.Find(b)
+}
+
+// Groups returns all the sets keyed by their representatives, the same shape maps.InvertGrouped produces.
+// Elements of a set go in the order they were added.
+func (d *DisjointSet[K]) Groups() map[K][]K {
+	out := make(map[K][]K, d.sets)
+	for i, item := range d.items {
+		r := d.items[d.root(i)]
+		out[r] = append(out[r], item)
+	}
+	return out
+}
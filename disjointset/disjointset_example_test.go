@@ -0,0 +1,38 @@
+package disjointset
+
+import (
+	"fmt"
+	"sort"
+)
+
+func ExampleDisjointSet_Groups() {
+	// accounts sharing an email belong to the same person
+	emails := map[string][]string{
+		"acc1": {"john@mail.com", "j@work.com"},
+		"acc2": {"john@home.com"},
+		"acc3": {"j@work.com", "john@home.com"},
+		"acc4": {"mary@mail.com"},
+	}
+
+	d := New("acc1", "acc2", "acc3", "acc4")
+	owner := map[string]string{}
+	for _, acc := range []string{"acc1", "acc2", "acc3", "acc4"} {
+		for _, email := range emails[acc] {
+			if prev, ok := owner[email]; ok {
+				d.Union(prev, acc)
+			}
+			owner[email] = acc
+		}
+	}
+
+	var people [][]string
+	for _, accounts := range d.Groups() {
+		people = append(people, accounts)
+	}
+	sort.Slice(people, func(i, j int) bool { return people[i][0] < people[j][0] })
+
+	fmt.Println(d.Count(), people)
+
+	// Output:
+	// 2 [[acc1 acc2 acc3] [acc4]]
+}
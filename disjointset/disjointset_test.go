@@ -0,0 +1,125 @@
+package disjointset
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/bullgare/funktional/maps"
+)
+
+func Test_DisjointSet(t *testing.T) {
+	d := New("a", "b", "c", "d", "e")
+
+	tt := []struct {
+		a, b     string
+		merged   bool
+		count    int
+		expected map[string][]string
+	}{
+		{a: "a", b: "b", merged: true, count: 4},
+		{a: "c", b: "d", merged: true, count: 3},
+		{a: "b", b: "a", merged: false, count: 3},
+		{a: "d", b: "b", merged: true, count: 2},
+		{a: "f", b: "f", merged: false, count: 3},
+		{a: "g", b: "e", merged: true, count: 3},
+	}
+
+	for _, tc := range tt {
+		if merged := d.Union(tc.a, tc.b); merged != tc.merged {
+			t.Fatalf("Union(%s, %s): expected %v, got %v", tc.a, tc.b, tc.merged, merged)
+		}
+		if d.Count() != tc.count {
+			t.Fatalf("Union(%s, %s): expected %d sets, got %d", tc.a, tc.b, tc.count, d.Count())
+		}
+	}
+
+	if d.Len() != 7 || !d.Has("g") || d.Has("x") {
+		t.Fatalf("DisjointSet: unexpected elements %v", d.items)
+	}
+	if !d.Connected("a", "c") || d.Connected("a", "e") || !d.Connected("g", "e") {
+		t.Fatal("Connected: unexpected result")
+	}
+	if d.Find("x") != "x" || d.Connected("x", "a") || d.Has("x") {
+		t.Fatal("Find: unknown element should be a representative of itself and should not be added")
+	}
+	if d.Add("a") || !d.Add("x") || d.Count() != 4 {
+		t.Fatal("Add: unexpected result")
+	}
+
+	groups := d.Groups()
+	expected := map[string][]string{
+		d.Find("a"): {"a", "b", "c", "d"},
+		d.Find("e"): {"e", "g"},
+		"f":         {"f"},
+		"x":         {"x"},
+	}
+	if !reflect.DeepEqual(groups, expected) {
+		t.Fatalf(`Groups: expected
+				%#v, got
+				%#v`, expected, groups)
+	}
+}
+
+func Test_DisjointSet_ZeroValue(t *testing.T) {
+	var d DisjointSet[int]
+	if d.Count() != 0 || len(d.Groups()) != 0 || d.Connected(1, 2) {
+		t.Fatal("DisjointSet: zero value should be empty")
+	}
+
+	d.Union(1, 2)
+	if !d.Connected(2, 1) || d.Count() != 1 {
+		t.Fatal("DisjointSet: zero value should be ready to use")
+	}
+}
+
+// Test_DisjointSet_Random checks the structure against naive relabeling of components.
+func Test_DisjointSet_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	const n = 200
+
+	d := New[int]()
+	labels := make(map[int]int, n)
+	for i := 0; i < n; i++ {
+		d.Add(i)
+		labels[i] = i
+	}
+
+	for step := 0; step < 300; step++ {
+		a, b := r.Intn(n), r.Intn(n)
+		d.Union(a, b)
+
+		from, to := labels[b], labels[a]
+		for k, l := range labels {
+			if l == from {
+				labels[k] = to
+			}
+		}
+
+		x, y := r.Intn(n), r.Intn(n)
+		if d.Connected(x, y) != (labels[x] == labels[y]) {
+			t.Fatalf("Connected(%d, %d): expected %v at step %d", x, y, labels[x] == labels[y], step)
+		}
+	}
+
+	groups := normalize(d.Groups())
+	expected := normalize(maps.InvertGrouped(labels))
+	if !reflect.DeepEqual(groups, expected) {
+		t.Fatalf("Groups: expected %v, got %v", expected, groups)
+	}
+	if d.Count() != len(expected) {
+		t.Fatalf("Count: expected %d, got %d", len(expected), d.Count())
+	}
+}
+
+// normalize drops the representatives and sorts the groups.
+func normalize(groups map[int][]int) [][]int {
+	res := make([][]int, 0, len(groups))
+	for _, g := range groups {
+		sort.Ints(g)
+		res = append(res, g)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i][0] < res[j][0] })
+	return res
+}
@@ -0,0 +1,352 @@
+// Package equality implements configurable deep equality,
+// an alternative to reflect.DeepEqual which reports where the values differ.
+package equality
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+)
+
+// Option configures Equal and Diff.
+type Option func(*config)
+
+type config struct {
+	nilEqualsEmpty bool
+	ignoreOrder    bool
+	floatTolerance float64
+	ignoreFields   map[string]bool
+	comparators    map[reflect.Type]func(a, b reflect.Value) bool
+}
+
+// NilEqualsEmpty makes nil and empty slices (and maps) equal.
+func NilEqualsEmpty() Option {
+	return func(c *config) {
+		c.nilEqualsEmpty = true
+	}
+}
+
+// IgnoreOrder makes slices equal if they have the same elements in any order (with the same number of repetitions).
+// It compares O(n^2) pairs of elements per slice and finds a pairing even with FloatTolerance,
+// where the first element within tolerance is not always the right pair.
+func IgnoreOrder() Option {
+	return func(c *config) {
+		c.ignoreOrder = true
+	}
+}
+
+// FloatTolerance makes floats equal if they differ by no more than tolerance.
+func FloatTolerance(tolerance float64) Option {
+	return func(c *config) {
+		c.floatTolerance = tolerance
+	}
+}
+
+// IgnoreFields skips struct fields with the given names (in any struct).
+func IgnoreFields(names ...string) Option {
+	return func(c *config) {
+		if c.ignoreFields == nil {
+			c.ignoreFields = make(map[string]bool, len(names))
+		}
+		for _, name := range names {
+			c.ignoreFields[name] = true
+		}
+	}
+}
+
+// Comparator sets a function to compare all values of type T.
+// If T is an interface, it is used for values which are statically typed as T (like struct fields or slice elements).
+// It is not used for unexported struct fields.
+func Comparator[T any](eq func(a, b T) bool) Option {
+	return func(c *config) {
+		if c.comparators == nil {
+			c.comparators = make(map[reflect.Type]func(a, b reflect.Value) bool)
+		}
+		c.comparators[reflect.TypeOf((*T)(nil)).Elem()] = func(a, b reflect.Value) bool {
+			// nil interfaces come as the zero T
+			x, _ := a.Interface().(T)
+			y, _ := b.Interface().(T)
+			return eq(x, y)
+		}
+	}
+}
+
+// Equal reports whether a and b are deeply equal.
+// Without options it behaves like reflect.DeepEqual.
+func Equal(a, b any, opts ...Option) bool {
+	return Diff(a, b, opts...) == ""
+}
+
+// Diff returns a readable description of the first difference between a and b, starting with a path to it,
+// like `.Users[1].Name: "a" != "b"`. It returns an empty string if the values are equal.
+// Without options it treats values the same way as reflect.DeepEqual.
+func Diff(a, b any, opts ...Option) string {
+	c := &config{}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	cmp := comparer{config: c, visited: make(map[visit]bool)}
+	return cmp.diff(reflect.ValueOf(a), reflect.ValueOf(b), "")
+}
+
+type visit struct {
+	a, b uintptr
+	typ  reflect.Type
+}
+
+type comparer struct {
+	*config
+	visited map[visit]bool
+}
+
+func difference(path, format string, args ...any) string {
+	if path == "" {
+		return fmt.Sprintf(format, args...)
+	}
+	return path + ": " + fmt.Sprintf(format, args...)
+}
+
+func valuesDiffer(path string, a, b reflect.Value) string {
+	return difference(path, "%#v != %#v", a, b)
+}
+
+func (c comparer) diff(a, b reflect.Value, path string) string {
+	if !a.IsValid() || !b.IsValid() {
+		if a.IsValid() != b.IsValid() {
+			return valuesDiffer(path, a, b)
+		}
+		return ""
+	}
+	if a.Type() != b.Type() {
+		return difference(path, "type %v != type %v", a.Type(), b.Type())
+	}
+
+	if eq, ok := c.comparators[a.Type()]; ok && a.CanInterface() && b.CanInterface() {
+		if !eq(a, b) {
+			return valuesDiffer(path, a, b)
+		}
+		return ""
+	}
+
+	switch a.Kind() {
+	case reflect.Pointer:
+		if a.Pointer() == b.Pointer() {
+			return ""
+		}
+		if a.IsNil() || b.IsNil() {
+			return valuesDiffer(path, a, b)
+		}
+		if c.seen(a, b) {
+			return ""
+		}
+		return c.diff(a.Elem(), b.Elem(), path)
+
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				return valuesDiffer(path, a, b)
+			}
+			return ""
+		}
+		return c.diff(a.Elem(), b.Elem(), path)
+
+	case reflect.Slice:
+		if a.IsNil() != b.IsNil() && !(c.nilEqualsEmpty && a.Len() == 0 && b.Len() == 0) {
+			return valuesDiffer(path, a, b)
+		}
+		if a.Len() != b.Len() {
+			return difference(path, "length %d != length %d", a.Len(), b.Len())
+		}
+		if a.Len() == 0 || (a.Pointer() == b.Pointer() && !c.ignoreOrder) {
+			return ""
+		}
+		if c.seen(a, b) {
+			return ""
+		}
+		if c.ignoreOrder {
+			return c.diffUnordered(a, b, path)
+		}
+		return c.diffSequence(a, b, path)
+
+	case reflect.Array:
+		if c.ignoreOrder {
+			return c.diffUnordered(a, b, path)
+		}
+		return c.diffSequence(a, b, path)
+
+	case reflect.Map:
+		if a.IsNil() != b.IsNil() && !(c.nilEqualsEmpty && a.Len() == 0 && b.Len() == 0) {
+			return valuesDiffer(path, a, b)
+		}
+		if a.Len() == 0 && b.Len() == 0 || a.Pointer() == b.Pointer() {
+			return ""
+		}
+		if c.seen(a, b) {
+			return ""
+		}
+		return c.diffMap(a, b, path)
+
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			name := a.Type().Field(i).Name
+			if c.ignoreFields[name] {
+				continue
+			}
+			if d := c.diff(a.Field(i), b.Field(i), path+"."+name); d != "" {
+				return d
+			}
+		}
+		return ""
+
+	case reflect.Float32, reflect.Float64:
+		if a.Float() == b.Float() || math.Abs(a.Float()-b.Float()) <= c.floatTolerance {
+			return ""
+		}
+		return valuesDiffer(path, a, b)
+
+	case reflect.Complex64, reflect.Complex128:
+		if a.Complex() == b.Complex() {
+			return ""
+		}
+		return valuesDiffer(path, a, b)
+
+	case reflect.Bool:
+		if a.Bool() == b.Bool() {
+			return ""
+		}
+		return valuesDiffer(path, a, b)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if a.Int() == b.Int() {
+			return ""
+		}
+		return valuesDiffer(path, a, b)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if a.Uint() == b.Uint() {
+			return ""
+		}
+		return valuesDiffer(path, a, b)
+
+	case reflect.String:
+		if a.String() == b.String() {
+			return ""
+		}
+		return valuesDiffer(path, a, b)
+
+	case reflect.Func:
+		// the same as reflect.DeepEqual: functions are equal only if both are nil
+		if a.IsNil() && b.IsNil() {
+			return ""
+		}
+		return difference(path, "functions are not comparable")
+
+	default:
+		// channels and unsafe pointers
+		if a.Pointer() == b.Pointer() {
+			return ""
+		}
+		return valuesDiffer(path, a, b)
+	}
+}
+
+// seen marks a pair of references as being compared, so cycles are not followed forever.
+func (c comparer) seen(a, b reflect.Value) bool {
+	v := visit{a: a.Pointer(), b: b.Pointer(), typ: a.Type()}
+	if c.visited[v] {
+		return true
+	}
+	c.visited[v] = true
+	return false
+}
+
+func (c comparer) diffSequence(a, b reflect.Value, path string) string {
+	for i := 0; i < a.Len(); i++ {
+		if d := c.diff(a.Index(i), b.Index(i), fmt.Sprintf("%s[%d]", path, i)); d != "" {
+			return d
+		}
+	}
+	return ""
+}
+
+// diffUnordered pairs elements with bipartite matching (augmenting paths), as with a non-transitive comparison,
+// like FloatTolerance, taking the first match for every element can leave another one without a pair.
+func (c comparer) diffUnordered(a, b reflect.Value, path string) string {
+	// equal[i][j] caches comparisons: 0 is not compared yet, 1 is equal, 2 is different
+	equal := make([][]byte, a.Len())
+	for i := range equal {
+		equal[i] = make([]byte, b.Len())
+	}
+	isEqual := func(i, j int) bool {
+		if equal[i][j] == 0 {
+			// every attempt gets its own visited references, as a failed attempt should not affect the others
+			attempt := comparer{config: c.config, visited: make(map[visit]bool)}
+			equal[i][j] = 2
+			if attempt.diff(a.Index(i), b.Index(j), "") == "" {
+				equal[i][j] = 1
+			}
+		}
+		return equal[i][j] == 1
+	}
+
+	// pairOf[j] is the element of a paired with the j-th element of b, or -1
+	pairOf := make([]int, b.Len())
+	for j := range pairOf {
+		pairOf[j] = -1
+	}
+
+	var augment func(i int, tried []bool) bool
+	augment = func(i int, tried []bool) bool {
+		for j := range pairOf {
+			if tried[j] || !isEqual(i, j) {
+				continue
+			}
+			tried[j] = true
+			if pairOf[j] < 0 || augment(pairOf[j], tried) {
+				pairOf[j] = i
+				return true
+			}
+		}
+		return false
+	}
+
+	for i := 0; i < a.Len(); i++ {
+		if !augment(i, make([]bool, b.Len())) {
+			return difference(fmt.Sprintf("%s[%d]", path, i), "%#v has no match", a.Index(i))
+		}
+	}
+	return ""
+}
+
+func (c comparer) diffMap(a, b reflect.Value, path string) string {
+	for _, k := range sortedKeys(a) {
+		keyPath := fmt.Sprintf("%s[%#v]", path, k)
+		bv := b.MapIndex(k)
+		if !bv.IsValid() {
+			return difference(keyPath, "missing in the second value")
+		}
+		if d := c.diff(a.MapIndex(k), bv, keyPath); d != "" {
+			return d
+		}
+	}
+
+	if a.Len() != b.Len() {
+		for _, k := range sortedKeys(b) {
+			if !a.MapIndex(k).IsValid() {
+				return difference(fmt.Sprintf("%s[%#v]", path, k), "missing in the first value")
+			}
+		}
+	}
+	return ""
+}
+
+// sortedKeys returns map keys sorted by their string representation, so the first difference is always the same.
+func sortedKeys(m reflect.Value) []reflect.Value {
+	keys := m.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprintf("%#v", keys[i]) < fmt.Sprintf("%#v", keys[j])
+	})
+	return keys
+}
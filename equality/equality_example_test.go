@@ -0,0 +1,33 @@
+package equality
+
+import "fmt"
+
+func ExampleDiff() {
+	type user struct {
+		Name      string
+		Tags      []string
+		UpdatedAt int
+	}
+
+	a := []user{{Name: "a", Tags: nil, UpdatedAt: 1}, {Name: "b"}}
+	b := []user{{Name: "a", Tags: []string{}, UpdatedAt: 2}, {Name: "c"}}
+
+	fmt.Println(Diff(a, b))
+	fmt.Println(Diff(a, b, NilEqualsEmpty(), IgnoreFields("UpdatedAt")))
+
+	// Output:
+	// [0].Tags: []string(nil) != []string{}
+	// [1].Name: "b" != "c"
+}
+
+func ExampleEqual() {
+	a := map[string][]float64{"x": {1.0 / 3, 1}}
+	b := map[string][]float64{"x": {1, 0.3333333333}}
+
+	fmt.Println(Equal(a, b))
+	fmt.Println(Equal(a, b, IgnoreOrder(), FloatTolerance(1e-9)))
+
+	// Output:
+	// false
+	// true
+}
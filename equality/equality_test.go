@@ -0,0 +1,207 @@
+package equality
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type user struct {
+	Name      string
+	Tags      []string
+	Score     float64
+	UpdatedAt int
+	friends   []*user
+}
+
+func Test_Diff(t *testing.T) {
+	tt := []struct {
+		name     string
+		a        any
+		b        any
+		opts     []Option
+		expected string
+	}{
+		{
+			name:     "equal structs",
+			a:        user{Name: "a", Tags: []string{"x"}},
+			b:        user{Name: "a", Tags: []string{"x"}},
+			expected: "",
+		},
+		{
+			name:     "different field",
+			a:        []user{{Name: "a"}, {Name: "b"}},
+			b:        []user{{Name: "a"}, {Name: "c"}},
+			expected: `[1].Name: "b" != "c"`,
+		},
+		{
+			name:     "different unexported field",
+			a:        user{friends: []*user{{Name: "a"}}},
+			b:        user{friends: []*user{{Name: "b"}}},
+			expected: `.friends[0].Name: "a" != "b"`,
+		},
+		{
+			name:     "root value",
+			a:        1,
+			b:        2,
+			expected: `1 != 2`,
+		},
+		{
+			name:     "different types",
+			a:        1,
+			b:        int64(1),
+			expected: `type int != type int64`,
+		},
+		{
+			name:     "different length",
+			a:        map[string][]int{"a": {1}},
+			b:        map[string][]int{"a": {1, 2}},
+			expected: `["a"]: length 1 != length 2`,
+		},
+		{
+			name:     "missing key",
+			a:        map[string]int{"a": 1, "b": 2},
+			b:        map[string]int{"a": 1, "c": 2},
+			expected: `["b"]: missing in the second value`,
+		},
+		{
+			name:     "extra key",
+			a:        map[string]int{"a": 1},
+			b:        map[string]int{"a": 1, "c": 2},
+			expected: `["c"]: missing in the first value`,
+		},
+		{
+			name:     "nil and empty differ by default",
+			a:        user{Tags: nil},
+			b:        user{Tags: []string{}},
+			expected: `.Tags: []string(nil) != []string{}`,
+		},
+		{
+			name: "nil equals empty",
+			a:    map[string]any{"a": user{Tags: nil}, "b": map[int]int(nil)},
+			b:    map[string]any{"a": user{Tags: []string{}}, "b": map[int]int{}},
+			opts: []Option{NilEqualsEmpty()},
+		},
+		{
+			name:     "order matters by default",
+			a:        []int{1, 2, 2},
+			b:        []int{2, 1, 2},
+			expected: `[0]: 1 != 2`,
+		},
+		{
+			name: "ignore order",
+			a:    []user{{Name: "a", Tags: []string{"x", "y"}}, {Name: "b"}},
+			b:    []user{{Name: "b"}, {Name: "a", Tags: []string{"y", "x"}}},
+			opts: []Option{IgnoreOrder()},
+		},
+		{
+			name:     "ignore order - repetitions count",
+			a:        []int{1, 2, 2},
+			b:        []int{2, 1, 1},
+			opts:     []Option{IgnoreOrder()},
+			expected: `[2]: 2 has no match`,
+		},
+		{
+			name: "ignore order with tolerance - a pairing exists, even if the first match is not in it",
+			a:    []float64{1.0, 1.1},
+			b:    []float64{1.05, 0.95},
+			opts: []Option{IgnoreOrder(), FloatTolerance(0.1)},
+		},
+		{
+			name:     "ignore order with tolerance - no pairing",
+			a:        []float64{1.0, 1.1},
+			b:        []float64{1.05, 0.8},
+			opts:     []Option{IgnoreOrder(), FloatTolerance(0.1)},
+			expected: `[1]: 1.1 has no match`,
+		},
+		{
+			name:     "float without tolerance",
+			a:        user{Score: 1.0 / 3},
+			b:        user{Score: 0.3333333333},
+			expected: `.Score: 0.3333333333333333 != 0.3333333333`,
+		},
+		{
+			name: "float tolerance",
+			a:    user{Score: 1.0 / 3},
+			b:    user{Score: 0.3333333333},
+			opts: []Option{FloatTolerance(1e-9)},
+		},
+		{
+			name: "ignore fields",
+			a:    user{Name: "a", UpdatedAt: 1},
+			b:    user{Name: "a", UpdatedAt: 2},
+			opts: []Option{IgnoreFields("UpdatedAt")},
+		},
+		{
+			name: "custom comparator",
+			a:    []string{"Hello", "World"},
+			b:    []string{"hello", "WORLD"},
+			opts: []Option{Comparator(strings.EqualFold)},
+		},
+		{
+			name:     "custom comparator - difference",
+			a:        user{Name: "Hello"},
+			b:        user{Name: "bye"},
+			opts:     []Option{Comparator(strings.EqualFold)},
+			expected: `.Name: "Hello" != "bye"`,
+		},
+		{
+			name: "custom comparator - nil interface",
+			a:    struct{ Err error }{},
+			b:    struct{ Err error }{},
+			opts: []Option{Comparator(func(a, b error) bool { return a == b })},
+		},
+		{
+			name:     "NaN is not equal to itself, like in reflect.DeepEqual",
+			a:        math.NaN(),
+			b:        math.NaN(),
+			expected: `NaN != NaN`,
+		},
+		{
+			name:     "nil and non-nil",
+			a:        nil,
+			b:        1,
+			expected: `<invalid reflect.Value> != 1`,
+		},
+		{
+			name: "nil and nil",
+			a:    nil,
+			b:    nil,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			res := Diff(tc.a, tc.b, tc.opts...)
+			if res != tc.expected {
+				t.Fatalf(`Diff %s: expected
+				%s, got
+				%s`, tc.name, tc.expected, res)
+			}
+
+			if Equal(tc.a, tc.b, tc.opts...) != (tc.expected == "") {
+				t.Fatalf("Equal %s: should be consistent with Diff", tc.name)
+			}
+		})
+	}
+}
+
+func Test_Equal_LikeDeepEqual(t *testing.T) {
+	a := &user{Name: "a"}
+	a.friends = []*user{a}
+	b := &user{Name: "a"}
+	b.friends = []*user{b}
+
+	values := []any{
+		1, "a", []int{1}, []int(nil), map[string]int{"a": 1}, a, b, [2]int{1, 2}, struct{ F func() }{},
+	}
+
+	for _, x := range values {
+		for _, y := range values {
+			if Equal(x, y) != reflect.DeepEqual(x, y) {
+				t.Fatalf("Equal(%#v, %#v) is not consistent with reflect.DeepEqual", x, y)
+			}
+		}
+	}
+}
@@ -0,0 +1,230 @@
+// Package funkgentest checks that functions generated by cmd/funkgen behave exactly like the generic ones.
+// It is used by the generated tests.
+package funkgentest
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// Runs is the number of random calls Equivalent makes.
+const Runs = 200
+
+// maxMapLen keeps results independent of the iteration order of maps.
+const maxMapLen = 1
+
+// Equivalent calls two functions of the same type with the same random arguments and fails the test
+// if they return different results, panic differently, change their arguments differently
+// or call callbacks with different arguments.
+//
+// Callbacks are random functions: a callback returns the same values for the same arguments.
+// As the iteration order of maps is random, maps in arguments have at most one key.
+func Equivalent(t testing.TB, generic, specialized any) {
+	t.Helper()
+
+	gv, sv := reflect.ValueOf(generic), reflect.ValueOf(specialized)
+	if gv.Kind() != reflect.Func || gv.Type() != sv.Type() {
+		t.Fatalf("funkgentest: expected two functions of the same type, got %T and %T", generic, specialized)
+	}
+
+	for run := int64(0); run < Runs; run++ {
+		g := newCall(gv, run)
+		s := newCall(sv, run)
+
+		switch {
+		case g.panicked != s.panicked:
+			t.Fatalf("funkgentest: run %d, panics differ\n\targs: %s\n\tgeneric: %s\n\tspecialized: %s", run, g.args, g.panicked, s.panicked)
+		case g.results != s.results:
+			t.Fatalf("funkgentest: run %d, results differ\n\targs: %s\n\tgeneric: %s\n\tspecialized: %s", run, g.args, g.results, s.results)
+		case g.argsAfter != s.argsAfter:
+			t.Fatalf("funkgentest: run %d, arguments are changed differently\n\targs: %s\n\tgeneric: %s\n\tspecialized: %s", run, g.args, g.argsAfter, s.argsAfter)
+		case !reflect.DeepEqual(g.calls, s.calls):
+			t.Fatalf("funkgentest: run %d, callbacks are called differently\n\targs: %s\n\tgeneric: %v\n\tspecialized: %v", run, g.args, g.calls, s.calls)
+		}
+	}
+}
+
+type call struct {
+	args      string
+	argsAfter string
+	results   string
+	panicked  string
+	calls     []string
+}
+
+// newCall calls a function with random arguments, the same ones for the same seed.
+func newCall(fn reflect.Value, seed int64) *call {
+	c := &call{}
+	g := generator{r: rand.New(rand.NewSource(seed)), calls: &c.calls}
+
+	typ := fn.Type()
+	args := make([]reflect.Value, typ.NumIn())
+	for i := range args {
+		args[i] = g.value(typ.In(i))
+	}
+	c.args = formatValues(args)
+
+	var results []reflect.Value
+	func() {
+		defer func() {
+			if p := recover(); p != nil {
+				c.panicked = fmt.Sprint(p)
+			}
+		}()
+		if typ.IsVariadic() {
+			results = fn.CallSlice(args)
+		} else {
+			results = fn.Call(args)
+		}
+	}()
+
+	c.results = formatValues(results)
+	c.argsAfter = formatValues(args)
+
+	return c
+}
+
+type generator struct {
+	r     *rand.Rand
+	calls *[]string
+}
+
+// value generates a random value of a type. Numbers are small to get equal values and valid indexes often.
+func (g generator) value(typ reflect.Type) reflect.Value {
+	v := reflect.New(typ).Elem()
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		v.SetBool(g.r.Intn(2) == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(int64(g.r.Intn(21) - 5))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v.SetUint(uint64(g.r.Intn(16)))
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(float64(g.r.Intn(21)-5) / 2)
+	case reflect.Complex64, reflect.Complex128:
+		v.SetComplex(complex(float64(g.r.Intn(5)), float64(g.r.Intn(5))))
+	case reflect.String:
+		b := make([]byte, g.r.Intn(4))
+		for i := range b {
+			b[i] = "abc"[g.r.Intn(3)]
+		}
+		v.SetString(string(b))
+	case reflect.Slice:
+		if g.r.Intn(5) == 0 {
+			break
+		}
+		n := g.r.Intn(7)
+		v.Set(reflect.MakeSlice(typ, n, n))
+		for i := 0; i < n; i++ {
+			v.Index(i).Set(g.value(typ.Elem()))
+		}
+	case reflect.Array:
+		for i := 0; i < typ.Len(); i++ {
+			v.Index(i).Set(g.value(typ.Elem()))
+		}
+	case reflect.Map:
+		if g.r.Intn(5) == 0 {
+			break
+		}
+		v.Set(reflect.MakeMap(typ))
+		for i := g.r.Intn(maxMapLen + 1); i > 0; i-- {
+			v.SetMapIndex(g.value(typ.Key()), g.value(typ.Elem()))
+		}
+	case reflect.Pointer:
+		if g.r.Intn(5) == 0 {
+			break
+		}
+		p := reflect.New(typ.Elem())
+		p.Elem().Set(g.value(typ.Elem()))
+		v.Set(p)
+	case reflect.Struct:
+		for i := 0; i < typ.NumField(); i++ {
+			if typ.Field(i).IsExported() {
+				v.Field(i).Set(g.value(typ.Field(i).Type))
+			}
+		}
+	case reflect.Func:
+		v.Set(g.function(typ, g.r.Int63()))
+	}
+
+	return v
+}
+
+// function creates a random function: its results depend on its arguments only. Calls are recorded.
+func (g generator) function(typ reflect.Type, seed int64) reflect.Value {
+	return reflect.MakeFunc(typ, func(args []reflect.Value) []reflect.Value {
+		key := formatValues(args)
+		*g.calls = append(*g.calls, key)
+
+		h := fnv.New64a()
+		h.Write([]byte(key))
+		inner := generator{r: rand.New(rand.NewSource(seed ^ int64(h.Sum64()))), calls: g.calls}
+
+		res := make([]reflect.Value, typ.NumOut())
+		for i := range res {
+			res[i] = inner.value(typ.Out(i))
+		}
+		return res
+	})
+}
+
+func formatValues(values []reflect.Value) string {
+	parts := make([]string, 0, len(values))
+	for _, v := range values {
+		parts = append(parts, format(v))
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+// format prints a value following pointers and skipping functions, so equal values print the same way.
+func format(v reflect.Value) string {
+	if !v.IsValid() {
+		return "<nil>"
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return "nil"
+		}
+		return "&" + format(v.Elem())
+	case reflect.Func:
+		return "func"
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return "nil"
+		}
+		parts := make([]string, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			parts = append(parts, format(v.Index(i)))
+		}
+		return "[" + strings.Join(parts, " ") + "]"
+	case reflect.Map:
+		if v.IsNil() {
+			return "nil"
+		}
+		parts := make([]string, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			parts = append(parts, format(iter.Key())+":"+format(iter.Value()))
+		}
+		sort.Strings(parts)
+		return "map[" + strings.Join(parts, " ") + "]"
+	case reflect.Struct:
+		parts := make([]string, 0, v.NumField())
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				parts = append(parts, format(v.Field(i)))
+			}
+		}
+		return "{" + strings.Join(parts, " ") + "}"
+	default:
+		return fmt.Sprintf("%#v", v.Interface())
+	}
+}
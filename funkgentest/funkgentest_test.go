@@ -0,0 +1,119 @@
+package funkgentest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bullgare/funktional/maps"
+	"github.com/bullgare/funktional/slices"
+)
+
+// recorder catches failures of Equivalent.
+type recorder struct {
+	testing.TB
+	failure string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.failure = format
+	panic(r)
+}
+
+func failure(generic, specialized any) (res string) {
+	r := &recorder{}
+	defer func() {
+		if p := recover(); p != nil && p != r {
+			panic(p)
+		}
+		res = r.failure
+	}()
+	Equivalent(r, generic, specialized)
+	return ""
+}
+
+func Test_Equivalent(t *testing.T) {
+	filter := func(in []int, f func(int, int, []int) bool) []int {
+		if in == nil {
+			return nil
+		}
+		res := []int{}
+		for i, v := range in {
+			if f(v, i, in) {
+				res = append(res, v)
+			}
+		}
+		return res
+	}
+
+	tt := []struct {
+		name        string
+		generic     any
+		specialized any
+		failure     string
+	}{
+		{
+			name:        "same behaviour",
+			generic:     slices.Filter[int],
+			specialized: filter,
+		},
+		{
+			name:    "different results",
+			generic: slices.Filter[int],
+			specialized: func(in []int, f func(int, int, []int) bool) []int {
+				return filter(in, func(v int, i int, all []int) bool { return f(v, i, all) && v != 3 })
+			},
+			failure: "results differ",
+		},
+		{
+			name:    "nil is not an empty slice",
+			generic: slices.Filter[int],
+			specialized: func(in []int, f func(int, int, []int) bool) []int {
+				return filter(append([]int{}, in...), f)
+			},
+			failure: "results differ",
+		},
+		{
+			name:    "callbacks are called differently",
+			generic: slices.FindIndex[string],
+			specialized: func(in []string, f func(string) bool, from ...int) int {
+				for _, v := range in {
+					f(v)
+				}
+				return slices.FindIndex(in, f, from...)
+			},
+			failure: "callbacks are called differently",
+		},
+		{
+			name:        "arguments are changed differently",
+			generic:     slices.ReverseInPlace[bool],
+			specialized: func(in []bool) {},
+			failure:     "arguments are changed differently",
+		},
+		{
+			name:    "panics differ",
+			generic: maps.Keys[int, string],
+			specialized: func(in map[string]int) []string {
+				return maps.Keys(in)[:1]
+			},
+			failure: "panics differ",
+		},
+		{
+			name:        "different types",
+			generic:     maps.Keys[int, string],
+			specialized: maps.Keys[int, int],
+			failure:     "functions of the same type",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			res := failure(tc.generic, tc.specialized)
+
+			if tc.failure == "" && res != "" || !strings.Contains(res, tc.failure) {
+				t.Fatalf("Equivalent %s: expected failure %q, got %q", tc.name, tc.failure, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+// Command funkvet reports misuse of funktional helpers, see package funkvet. It is run with go vet:
+//
+//	go vet -vettool=$(which funkvet) ./...
+//
+// Suggestions for hand-written loops are turned off with -funkvet.loops=false.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/unitchecker"
+
+	"github.com/bullgare/funktional/funkvet"
+)
+
+func main() {
+	unitchecker.Main(funkvet.Analyzer)
+}
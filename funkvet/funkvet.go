@@ -0,0 +1,428 @@
+// Package funkvet defines an analyzer which reports misuse of funktional helpers:
+//
+//   - relying on the order of maps.Keys and maps.Values, which is random;
+//   - ignoring the result of slices.Remove, or the removed elements only, which slices.Filter does cheaper;
+//   - modifying the original slice or map (the third argument) inside callbacks of slices.Map, maps.Filter etc.;
+//   - hand-written map and filter loops, which can be replaced with slices.Map, slices.Filter, maps.Map etc.
+//
+// It is run with go vet:
+//
+//	go install github.com/bullgare/funktional/funkvet/cmd/funkvet@latest
+//	go vet -vettool=$(which funkvet) ./...
+package funkvet
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const (
+	slicesPath = "github.com/bullgare/funktional/slices"
+	mapsPath   = "github.com/bullgare/funktional/maps"
+)
+
+// Analyzer reports misuse of funktional helpers.
+var Analyzer = &analysis.Analyzer{
+	Name:     "funkvet",
+	Doc:      "report misuse of funktional helpers and loops which can be replaced with them",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+var suggestLoops bool
+
+func init() {
+	Analyzer.Flags.BoolVar(&suggestLoops, "loops", true, "suggest funktional replacements for hand-written map and filter loops")
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodes := []ast.Node{(*ast.CallExpr)(nil), (*ast.RangeStmt)(nil)}
+	insp.WithStack(nodes, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			fn := funktionalFunc(pass.TypesInfo, n)
+			if fn == nil {
+				return true
+			}
+			switch {
+			case fn.Pkg().Path() == mapsPath && (fn.Name() == "Keys" || fn.Name() == "Values"):
+				checkOrder(pass, n, fn, stack)
+			case fn.Pkg().Path() == slicesPath && fn.Name() == "Remove":
+				checkRemove(pass, n, stack)
+			}
+			checkCallbacks(pass, n, fn)
+		case *ast.RangeStmt:
+			if suggestLoops {
+				checkLoop(pass, n)
+			}
+		}
+
+		return true
+	})
+
+	return nil, nil
+}
+
+// funktionalFunc returns a function of funktional slices or maps packages a call is made to, or nil.
+func funktionalFunc(info *types.Info, call *ast.CallExpr) *types.Func {
+	fn, ok := typeutil.Callee(info, call).(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return nil
+	}
+	if path := fn.Pkg().Path(); path != slicesPath && path != mapsPath {
+		return nil
+	}
+	return fn
+}
+
+// checkOrder reports results of maps.Keys and maps.Values indexed, sliced or joined without sorting.
+func checkOrder(pass *analysis.Pass, call *ast.CallExpr, fn *types.Func, stack []ast.Node) {
+	if reliesOnOrder(pass.TypesInfo, call, stack[len(stack)-2]) {
+		pass.Reportf(call.Pos(), "maps.%s returns elements in random order: sort them before relying on the order", fn.Name())
+		return
+	}
+
+	obj := assignedTo(pass.TypesInfo, call, stack[len(stack)-2])
+	body := enclosingBody(stack)
+	if obj == nil || body == nil {
+		return
+	}
+
+	var sorted, relied bool
+	ast.Inspect(body, func(n ast.Node) bool {
+		if sorted {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			if isSort(pass.TypesInfo, n) && len(n.Args) > 0 && refersTo(pass.TypesInfo, n.Args[0], obj) {
+				sorted = true
+				return false
+			}
+			for _, arg := range n.Args {
+				if refersTo(pass.TypesInfo, arg, obj) && reliesOnOrder(pass.TypesInfo, arg, n) {
+					relied = true
+				}
+			}
+		case *ast.IndexExpr:
+			relied = relied || refersTo(pass.TypesInfo, n.X, obj)
+		case *ast.SliceExpr:
+			relied = relied || refersTo(pass.TypesInfo, n.X, obj)
+		}
+		return true
+	})
+
+	if relied && !sorted {
+		pass.Reportf(call.Pos(), "maps.%s returns elements in random order: sort %s before relying on the order", fn.Name(), obj.Name())
+	}
+}
+
+// reliesOnOrder reports whether an expression is indexed, sliced or joined into a string by its parent.
+func reliesOnOrder(info *types.Info, e ast.Expr, parent ast.Node) bool {
+	switch p := parent.(type) {
+	case *ast.IndexExpr:
+		return p.X == e
+	case *ast.SliceExpr:
+		return p.X == e
+	case *ast.CallExpr:
+		fn, ok := typeutil.Callee(info, p).(*types.Func)
+		return ok && fn.Pkg() != nil && fn.Pkg().Path() == "strings" && fn.Name() == "Join" && len(p.Args) > 0 && p.Args[0] == e
+	}
+	return false
+}
+
+// assignedTo returns a variable an expression is assigned to, or nil.
+func assignedTo(info *types.Info, e ast.Expr, parent ast.Node) types.Object {
+	var lhs ast.Expr
+	switch p := parent.(type) {
+	case *ast.AssignStmt:
+		if len(p.Lhs) == 1 && len(p.Rhs) == 1 && p.Rhs[0] == e {
+			lhs = p.Lhs[0]
+		}
+	case *ast.ValueSpec:
+		if len(p.Names) == 1 && len(p.Values) == 1 && p.Values[0] == e {
+			lhs = p.Names[0]
+		}
+	}
+
+	id, ok := lhs.(*ast.Ident)
+	if !ok || id.Name == "_" {
+		return nil
+	}
+	return info.ObjectOf(id)
+}
+
+// enclosingBody returns the body of the innermost function of a stack.
+func enclosingBody(stack []ast.Node) *ast.BlockStmt {
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch n := stack[i].(type) {
+		case *ast.FuncDecl:
+			return n.Body
+		case *ast.FuncLit:
+			return n.Body
+		}
+	}
+	return nil
+}
+
+// isSort reports whether a call sorts its first argument with sort, slices or funktional slices packages.
+func isSort(info *types.Info, call *ast.CallExpr) bool {
+	fn, ok := typeutil.Callee(info, call).(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return false
+	}
+	switch fn.Pkg().Path() {
+	case "sort":
+		return !strings.HasPrefix(fn.Name(), "Search")
+	case "slices", slicesPath:
+		return strings.HasPrefix(fn.Name(), "Sort")
+	}
+	return false
+}
+
+func refersTo(info *types.Info, e ast.Expr, obj types.Object) bool {
+	id, ok := astutil.Unparen(e).(*ast.Ident)
+	return ok && obj != nil && info.ObjectOf(id) == obj
+}
+
+// uses reports whether a variable is used anywhere in a node.
+func uses(info *types.Info, n ast.Node, obj types.Object) bool {
+	found := false
+	ast.Inspect(n, func(n ast.Node) bool {
+		if e, ok := n.(ast.Expr); ok && refersTo(info, e, obj) {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// checkRemove reports calls to slices.Remove with the removed elements or both results ignored.
+func checkRemove(pass *analysis.Pass, call *ast.CallExpr, stack []ast.Node) {
+	switch p := stack[len(stack)-2].(type) {
+	case *ast.ExprStmt:
+		pass.Reportf(call.Pos(), "result of slices.Remove is not used: it does not modify the original slice")
+	case *ast.AssignStmt:
+		if len(p.Lhs) == 2 && isBlank(p.Lhs[1]) && !isBlank(p.Lhs[0]) {
+			pass.Reportf(call.Pos(), "removed elements of slices.Remove are ignored: use slices.Filter with the opposite condition")
+		}
+	}
+}
+
+func isBlank(e ast.Expr) bool {
+	id, ok := e.(*ast.Ident)
+	return ok && id.Name == "_"
+}
+
+// checkCallbacks reports callbacks modifying their third argument, which is the original slice or map.
+func checkCallbacks(pass *analysis.Pass, call *ast.CallExpr, fn *types.Func) {
+	for _, arg := range call.Args {
+		lit, ok := arg.(*ast.FuncLit)
+		if !ok {
+			continue
+		}
+		orig := thirdParam(pass.TypesInfo, lit)
+		if orig == nil {
+			continue
+		}
+
+		ast.Inspect(lit.Body, func(n ast.Node) bool {
+			var pos token.Pos
+			switch n := n.(type) {
+			case *ast.AssignStmt:
+				if n.Tok == token.DEFINE {
+					break
+				}
+				for _, lhs := range n.Lhs {
+					if elementOf(pass.TypesInfo, lhs, orig) {
+						pos = lhs.Pos()
+					}
+				}
+			case *ast.IncDecStmt:
+				if elementOf(pass.TypesInfo, n.X, orig) {
+					pos = n.X.Pos()
+				}
+			case *ast.CallExpr:
+				if len(n.Args) > 0 && refersTo(pass.TypesInfo, n.Args[0], orig) && modifiesFirstArg(pass.TypesInfo, n) {
+					pos = n.Pos()
+				}
+			}
+
+			if pos.IsValid() {
+				pass.Reportf(pos, "callback of %s.%s modifies %s, which is the original collection: copy it first", fn.Pkg().Name(), fn.Name(), orig.Name())
+			}
+			return true
+		})
+	}
+}
+
+// thirdParam returns the third parameter of a function literal if it is a named slice or map.
+func thirdParam(info *types.Info, lit *ast.FuncLit) types.Object {
+	var names []*ast.Ident
+	for _, field := range lit.Type.Params.List {
+		if len(field.Names) == 0 {
+			return nil
+		}
+		names = append(names, field.Names...)
+	}
+	if len(names) != 3 || names[2].Name == "_" {
+		return nil
+	}
+
+	obj := info.ObjectOf(names[2])
+	if obj == nil {
+		return nil
+	}
+	switch obj.Type().Underlying().(type) {
+	case *types.Slice, *types.Map:
+		return obj
+	}
+	return nil
+}
+
+// elementOf reports whether an expression is an element of a collection or a part of an element.
+func elementOf(info *types.Info, e ast.Expr, obj types.Object) bool {
+	for {
+		switch x := astutil.Unparen(e).(type) {
+		case *ast.IndexExpr:
+			if refersTo(info, x.X, obj) {
+				return true
+			}
+			e = x.X
+		case *ast.SelectorExpr:
+			e = x.X
+		case *ast.StarExpr:
+			e = x.X
+		default:
+			return false
+		}
+	}
+}
+
+// modifiesFirstArg reports whether a call modifies elements of its first argument.
+func modifiesFirstArg(info *types.Info, call *ast.CallExpr) bool {
+	switch fn := typeutil.Callee(info, call).(type) {
+	case *types.Builtin:
+		// append overwrites elements after the length of a slice if it has capacity
+		return fn.Name() == "append" || fn.Name() == "delete" || fn.Name() == "clear"
+	case *types.Func:
+		if fn.Pkg() == nil {
+			return false
+		}
+		switch fn.Pkg().Path() {
+		case slicesPath:
+			return fn.Name() == "Fill" || fn.Name() == "ReverseInPlace"
+		case "slices", "maps":
+			return isSort(info, call) || fn.Name() == "Reverse" || fn.Name() == "DeleteFunc"
+		}
+		return isSort(info, call)
+	}
+	return false
+}
+
+// checkLoop reports range loops which only map or filter elements into another slice or map.
+func checkLoop(pass *analysis.Pass, loop *ast.RangeStmt) {
+	if len(loop.Body.List) != 1 {
+		return
+	}
+
+	var isMap bool
+	switch pass.TypesInfo.TypeOf(loop.X).Underlying().(type) {
+	case *types.Slice:
+	case *types.Map:
+		isMap = true
+	default:
+		return
+	}
+
+	key, value := rangeVar(pass.TypesInfo, loop.Key), rangeVar(pass.TypesInfo, loop.Value)
+
+	stmt, filtered, keyFilter := loop.Body.List[0], false, false
+	if ifStmt, ok := stmt.(*ast.IfStmt); ok {
+		if ifStmt.Init != nil || ifStmt.Else != nil || len(ifStmt.Body.List) != 1 {
+			return
+		}
+		stmt, filtered, keyFilter = ifStmt.Body.List[0], true, uses(pass.TypesInfo, ifStmt.Cond, key)
+	}
+
+	assign, ok := stmt.(*ast.AssignStmt)
+	if !ok || assign.Tok != token.ASSIGN || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+		return
+	}
+
+	var suggestion string
+	if elem, ok := appended(pass.TypesInfo, assign); ok {
+		if sameObject(pass.TypesInfo, assign.Lhs[0], loop.X) {
+			return
+		}
+		switch {
+		case !isMap && filtered && refersTo(pass.TypesInfo, elem, value):
+			suggestion = "slices.Filter"
+		case !isMap && !filtered && !refersTo(pass.TypesInfo, elem, value):
+			suggestion = "slices.Map"
+		case isMap && !filtered && refersTo(pass.TypesInfo, elem, key):
+			suggestion = "maps.Keys"
+		case isMap && !filtered && refersTo(pass.TypesInfo, elem, value):
+			suggestion = "maps.Values"
+		case isMap && filtered && !keyFilter && refersTo(pass.TypesInfo, elem, key):
+			suggestion = "maps.FindAllKeysBy"
+		}
+	} else if index, ok := assign.Lhs[0].(*ast.IndexExpr); ok && isMap && key != nil && refersTo(pass.TypesInfo, index.Index, key) {
+		if _, ok := pass.TypesInfo.TypeOf(index.X).Underlying().(*types.Map); !ok || sameObject(pass.TypesInfo, index.X, loop.X) {
+			return
+		}
+		switch isValue := refersTo(pass.TypesInfo, assign.Rhs[0], value); {
+		case filtered && isValue:
+			suggestion = "maps.Filter"
+		case !filtered && !isValue:
+			suggestion = "maps.Map"
+		}
+	}
+
+	if suggestion != "" {
+		pass.Reportf(loop.Pos(), "loop can be replaced with %s", suggestion)
+	}
+}
+
+// rangeVar returns a variable of a range clause, or nil if there is none.
+func rangeVar(info *types.Info, e ast.Expr) types.Object {
+	id, ok := e.(*ast.Ident)
+	if !ok || id.Name == "_" {
+		return nil
+	}
+	return info.ObjectOf(id)
+}
+
+// appended returns the element of an assignment like res = append(res, elem).
+func appended(info *types.Info, assign *ast.AssignStmt) (ast.Expr, bool) {
+	call, ok := assign.Rhs[0].(*ast.CallExpr)
+	if !ok || len(call.Args) != 2 || call.Ellipsis.IsValid() {
+		return nil, false
+	}
+	if fn, ok := typeutil.Callee(info, call).(*types.Builtin); !ok || fn.Name() != "append" {
+		return nil, false
+	}
+	if !sameObject(info, assign.Lhs[0], call.Args[0]) {
+		return nil, false
+	}
+	return call.Args[1], true
+}
+
+func sameObject(info *types.Info, a, b ast.Expr) bool {
+	id, ok := astutil.Unparen(a).(*ast.Ident)
+	return ok && refersTo(info, b, info.ObjectOf(id))
+}
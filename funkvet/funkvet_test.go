@@ -0,0 +1,13 @@
+package funkvet_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/bullgare/funktional/funkvet"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), funkvet.Analyzer, "a")
+}
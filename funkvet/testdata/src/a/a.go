@@ -0,0 +1,137 @@
+package a
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bullgare/funktional/maps"
+	"github.com/bullgare/funktional/slices"
+)
+
+func order(m map[string]int) {
+	_ = maps.Keys(m)[0]                // want `maps.Keys returns elements in random order`
+	_ = strings.Join(maps.Keys(m), "") // want `maps.Keys returns elements in random order`
+
+	values := maps.Values(m) // want `maps.Values returns elements in random order: sort values before relying on the order`
+	_ = values[1:]
+
+	keys := maps.Keys(m) // want `maps.Keys returns elements in random order: sort keys before relying on the order`
+	_ = strings.Join(keys, ",")
+
+	sorted := maps.Keys(m)
+	sort.Strings(sorted)
+	_ = sorted[0]
+
+	ranged := maps.Keys(m)
+	for range ranged {
+	}
+	_ = len(ranged)
+}
+
+func remove(in []int) {
+	slices.Remove(in, func(v, _ int) bool { return v > 0 }) // want `result of slices.Remove is not used`
+
+	kept, _ := slices.Remove(in, func(v, _ int) bool { return v > 0 }) // want `removed elements of slices.Remove are ignored`
+	_, removed := slices.Remove(in, func(v, _ int) bool { return v > 0 })
+	kept, removed = slices.Remove(in, func(v, _ int) bool { return v > 0 })
+	_, _ = kept, removed
+}
+
+type user struct {
+	name string
+}
+
+func callbacks(in []int, users []*user, m map[string]int) {
+	slices.Map(in, func(v, i int, all []int) int {
+		all[i] = v * 2 // want `callback of slices.Map modifies all, which is the original collection`
+		all[i]++       // want `callback of slices.Map modifies all`
+		return v
+	})
+	slices.Filter(in, func(v, i int, all []int) bool {
+		all = append(all, v)       // want `callback of slices.Filter modifies all`
+		sort.Ints(all)             // want `callback of slices.Filter modifies all`
+		slices.ReverseInPlace(all) // want `callback of slices.Filter modifies all`
+		return true
+	})
+	slices.Map(users, func(u *user, i int, all []*user) string {
+		all[i].name = "" // want `callback of slices.Map modifies all`
+		return u.name
+	})
+	maps.Filter(m, func(v int, k string, all map[string]int) bool {
+		delete(all, k) // want `callback of maps.Filter modifies all`
+		return true
+	})
+
+	slices.Map(in, func(v, i int, all []int) int {
+		copied := append([]int(nil), all...)
+		copied[i] = 0
+		return all[i] + len(copied)
+	})
+	slices.Map(in, func(v, i int, _ []int) int { return v })
+}
+
+func loops(in []int, m map[string]int) {
+	var strs []string
+	for _, v := range in { // want `loop can be replaced with slices.Map`
+		strs = append(strs, strconv.Itoa(v))
+	}
+
+	positive := make([]int, 0, len(in))
+	for _, v := range in { // want `loop can be replaced with slices.Filter`
+		if v > 0 {
+			positive = append(positive, v)
+		}
+	}
+
+	doubled := make(map[string]int, len(m))
+	for k, v := range m { // want `loop can be replaced with maps.Map`
+		doubled[k] = v * 2
+	}
+
+	big := make(map[string]int)
+	for k, v := range m { // want `loop can be replaced with maps.Filter`
+		if v > 10 {
+			big[k] = v
+		}
+	}
+
+	var keys []string
+	for k := range m { // want `loop can be replaced with maps.Keys`
+		keys = append(keys, k)
+	}
+
+	var values []int
+	for _, v := range m { // want `loop can be replaced with maps.Values`
+		values = append(values, v)
+	}
+
+	var bigKeys []string
+	for k, v := range m { // want `loop can be replaced with maps.FindAllKeysBy`
+		if v > 10 {
+			bigKeys = append(bigKeys, k)
+		}
+	}
+
+	// these loops do more than mapping or filtering
+	var copied []int
+	for _, v := range in {
+		copied = append(copied, v)
+	}
+	for k := range m {
+		if strings.HasPrefix(k, "a") {
+			keys = append(keys, k)
+		}
+	}
+	for i, v := range in {
+		if v > 0 {
+			positive = append(positive, v)
+		}
+		positive = append(positive, i)
+	}
+	for k, v := range m {
+		m[k] = v + 1
+	}
+
+	_, _, _, _, _, _, _, _ = strs, positive, doubled, big, keys, values, bigKeys, copied
+}
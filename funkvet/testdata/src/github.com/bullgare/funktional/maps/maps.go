@@ -0,0 +1,10 @@
+// Package maps is a stub of funktional maps for analyzer tests.
+package maps
+
+func Map[T, Y any, K comparable](in map[K]T, convert func(T, K, map[K]T) Y) map[K]Y { return nil }
+
+func Filter[T any, K comparable](in map[K]T, filter func(T, K, map[K]T) bool) map[K]T { return nil }
+
+func Keys[T any, K comparable](in map[K]T) []K { return nil }
+
+func Values[T any, K comparable](in map[K]T) []T { return nil }
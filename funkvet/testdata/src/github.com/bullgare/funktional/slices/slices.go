@@ -0,0 +1,12 @@
+// Package slices is a stub of funktional slices for analyzer tests.
+package slices
+
+func Map[T, Y any](in []T, convert func(T, int, []T) Y) []Y { return nil }
+
+func Filter[T any](in []T, filter func(T, int, []T) bool) []T { return nil }
+
+func Remove[T any](in []T, assertion func(T, int) bool) ([]T, []T) { return nil, nil }
+
+func Fill[T any](in []T, value T, start, end int) []T { return in }
+
+func ReverseInPlace[T any](in []T) {}
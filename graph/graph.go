@@ -0,0 +1,318 @@
+// Package graph implements algorithms over directed graphs represented as adjacency maps,
+// where g[k] is a list of nodes k has edges to (the shape maps.InvertGrouped produces).
+// Nodes which are only mentioned in the lists are part of the graph as well.
+// The order of the neighbours is respected, but the order of the keys of a map is random,
+// so results of the functions walking the whole graph can differ between calls (while staying valid).
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bullgare/funktional/constraints"
+	"github.com/bullgare/funktional/heap"
+)
+
+// ErrCycle is matched (via errors.Is) by CycleError.
+var ErrCycle = errors.New("graph: cycle detected")
+
+// CycleError is returned by TopologicalSort, Cycle is a path of nodes where the last one has an edge to the first one.
+type CycleError[K comparable] struct {
+	Cycle []K
+}
+
+func (e *CycleError[K]) Error() string {
+	parts := make([]string, 0, len(e.Cycle)+1)
+	for _, k := range e.Cycle {
+		parts = append(parts, fmt.Sprint(k))
+	}
+	if len(e.Cycle) > 0 {
+		parts = append(parts, fmt.Sprint(e.Cycle[0]))
+	}
+	return ErrCycle.Error() + ": " + strings.Join(parts, " -> ")
+}
+
+// Is makes CycleError match ErrCycle.
+func (e *CycleError[K]) Is(target error) bool {
+	return target == ErrCycle
+}
+
+// Nodes returns all the nodes of a graph: keys and all the nodes they have edges to.
+func Nodes[K comparable](g map[K][]K) []K {
+	seen := make(map[K]bool, len(g))
+	out := make([]K, 0, len(g))
+	add := func(k K) {
+		if !seen[k] {
+			seen[k] = true
+			out = append(out, k)
+		}
+	}
+
+	for k, edges := range g {
+		add(k)
+		for _, to := range edges {
+			add(to)
+		}
+	}
+	return out
+}
+
+// Reverse creates a graph with all the edges reversed.
+func Reverse[K comparable](g map[K][]K) map[K][]K {
+	if g == nil {
+		return nil
+	}
+
+	out := make(map[K][]K, len(g))
+	for from, edges := range g {
+		if _, ok := out[from]; !ok {
+			out[from] = nil
+		}
+		for _, to := range edges {
+			out[to] = append(out[to], from)
+		}
+	}
+	return out
+}
+
+// TopologicalSort orders all the nodes so that for every edge from -> to, from goes before to.
+// If g maps a node to its dependencies, reverse the result (or the graph) to get dependencies first.
+// If there is a cycle, it returns *CycleError with the nodes of one of the cycles.
+func TopologicalSort[K comparable](g map[K][]K) ([]K, error) {
+	const (
+		unvisited = iota
+		inProgress
+		done
+	)
+
+	state := make(map[K]int, len(g))
+	order := make([]K, 0, len(g))
+	var stack []K
+
+	var visit func(k K) error
+	visit = func(k K) error {
+		switch state[k] {
+		case done:
+			return nil
+		case inProgress:
+			for i := len(stack) - 1; i >= 0; i-- {
+				if stack[i] == k {
+					cycle := make([]K, len(stack)-i)
+					copy(cycle, stack[i:])
+					return &CycleError[K]{Cycle: cycle}
+				}
+			}
+		}
+
+		state[k] = inProgress
+		stack = append(stack, k)
+		for _, to := range g[k] {
+			if err := visit(to); err != nil {
+				return err
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[k] = done
+		order = append(order, k)
+		return nil
+	}
+
+	for _, k := range Nodes(g) {
+		if err := visit(k); err != nil {
+			return nil, err
+		}
+	}
+
+	// post-order has every node after all the nodes it has edges to
+	for i, j := 0, len(order)-1; i < j; i, j = i+1, j-1 {
+		order[i], order[j] = order[j], order[i]
+	}
+	return order, nil
+}
+
+// BFS walks the nodes reachable from start in breadth-first order, calling visit with the distance from start,
+// until visit returns false.
+func BFS[K comparable](g map[K][]K, start K, visit func(node K, depth int) bool) {
+	seen := map[K]bool{start: true}
+	level := []K{start}
+
+	for depth := 0; len(level) > 0; depth++ {
+		var next []K
+		for _, k := range level {
+			if !visit(k, depth) {
+				return
+			}
+			for _, to := range g[k] {
+				if !seen[to] {
+					seen[to] = true
+					next = append(next, to)
+				}
+			}
+		}
+		level = next
+	}
+}
+
+// DFS walks the nodes reachable from start in depth-first pre-order, until visit returns false.
+func DFS[K comparable](g map[K][]K, start K, visit func(node K) bool) {
+	seen := make(map[K]bool)
+
+	var walk func(k K) bool
+	walk = func(k K) bool {
+		seen[k] = true
+		if !visit(k) {
+			return false
+		}
+		for _, to := range g[k] {
+			if !seen[to] && !walk(to) {
+				return false
+			}
+		}
+		return true
+	}
+
+	walk(start)
+}
+
+// ConnectedComponents splits the nodes into groups connected by edges in any direction.
+func ConnectedComponents[K comparable](g map[K][]K) [][]K {
+	undirected := Reverse(g)
+	for from, edges := range g {
+		undirected[from] = append(undirected[from], edges...)
+	}
+
+	seen := make(map[K]bool, len(undirected))
+	var out [][]K
+	for _, k := range Nodes(g) {
+		if seen[k] {
+			continue
+		}
+		var component []K
+		BFS(undirected, k, func(node K, _ int) bool {
+			seen[node] = true
+			component = append(component, node)
+			return true
+		})
+		out = append(out, component)
+	}
+	return out
+}
+
+// StronglyConnectedComponents splits the nodes into groups where every node is reachable from every other one
+// (Tarjan's algorithm). Components are returned in reverse topological order: no edges go to the previous ones.
+func StronglyConnectedComponents[K comparable](g map[K][]K) [][]K {
+	index := make(map[K]int)
+	lowLink := make(map[K]int)
+	onStack := make(map[K]bool)
+	var stack []K
+	var out [][]K
+
+	var connect func(k K)
+	connect = func(k K) {
+		index[k] = len(index)
+		lowLink[k] = index[k]
+		stack = append(stack, k)
+		onStack[k] = true
+
+		for _, to := range g[k] {
+			if _, visited := index[to]; !visited {
+				connect(to)
+				if lowLink[to] < lowLink[k] {
+					lowLink[k] = lowLink[to]
+				}
+			} else if onStack[to] && index[to] < lowLink[k] {
+				lowLink[k] = index[to]
+			}
+		}
+
+		if lowLink[k] == index[k] {
+			var component []K
+			for {
+				last := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[last] = false
+				component = append(component, last)
+				if last == k {
+					break
+				}
+			}
+			out = append(out, component)
+		}
+	}
+
+	for _, k := range Nodes(g) {
+		if _, visited := index[k]; !visited {
+			connect(k)
+		}
+	}
+	return out
+}
+
+// ShortestPath finds a path with the least number of edges from one node to another (using BFS).
+// The path includes both ends. If there is no path, it returns nil and false.
+func ShortestPath[K comparable](g map[K][]K, from, to K) ([]K, bool) {
+	prev := map[K]K{}
+	found := false
+	BFS(g, from, func(node K, _ int) bool {
+		if node == to {
+			found = true
+			return false
+		}
+		for _, next := range g[node] {
+			if _, ok := prev[next]; !ok && next != from {
+				prev[next] = node
+			}
+		}
+		return true
+	})
+
+	if !found {
+		return nil, false
+	}
+	return buildPath(prev, from, to), true
+}
+
+// ShortestPathWeighted finds a path with the least total weight from one node to another (Dijkstra's algorithm).
+// Weights must not be negative. The path includes both ends. If there is no path, it returns nil, 0 and false.
+func ShortestPathWeighted[K comparable, W constraints.Number](g map[K][]K, from, to K, weight func(from, to K) W) ([]K, W, bool) {
+	dist := map[K]W{from: 0}
+	prev := map[K]K{}
+	done := map[K]bool{}
+
+	pq := heap.NewPriorityQueue[K, W](func(a, b W) bool { return a < b })
+	pq.Push(from, 0)
+	for pq.Len() > 0 {
+		node, d, _ := pq.Pop()
+		if node == to {
+			return buildPath(prev, from, to), d, true
+		}
+		done[node] = true
+
+		for _, next := range g[node] {
+			if done[next] {
+				continue
+			}
+			nd := d + weight(node, next)
+			if old, ok := dist[next]; !ok || nd < old {
+				dist[next] = nd
+				prev[next] = node
+				pq.Push(next, nd)
+			}
+		}
+	}
+
+	return nil, 0, false
+}
+
+func buildPath[K comparable](prev map[K]K, from, to K) []K {
+	path := []K{to}
+	for k := to; k != from; {
+		k = prev[k]
+		path = append(path, k)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
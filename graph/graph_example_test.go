@@ -0,0 +1,39 @@
+package graph
+
+import (
+	"fmt"
+
+	"github.com/bullgare/funktional/maps"
+)
+
+func ExampleTopologicalSort() {
+	// service -> services it depends on
+	dependsOn := map[string][]string{
+		"api":    {"db", "cache"},
+		"cache":  {"db"},
+		"worker": {"api"},
+	}
+
+	// dependencies go first in the reversed graph
+	order, err := TopologicalSort(Reverse(dependsOn))
+	fmt.Println(order, err)
+
+	_, err = TopologicalSort(map[string][]string{"a": {"b"}, "b": {"a"}})
+	fmt.Println(err != nil)
+
+	// Output:
+	// [db cache api worker] <nil>
+	// true
+}
+
+func ExampleShortestPath() {
+	// team -> owner, inverted into owner -> teams
+	owners := map[string]string{"search": "alice", "billing": "alice", "alice": "cto"}
+	g := maps.InvertGrouped(owners)
+
+	path, _ := ShortestPath(g, "cto", "billing")
+	fmt.Println(path)
+
+	// Output:
+	// [cto alice billing]
+}
@@ -0,0 +1,220 @@
+package graph
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/bullgare/funktional/maps"
+)
+
+func sortedComponents(components [][]string) [][]string {
+	for _, c := range components {
+		sort.Strings(c)
+	}
+	sort.Slice(components, func(i, j int) bool { return components[i][0] < components[j][0] })
+	return components
+}
+
+func Test_TopologicalSort(t *testing.T) {
+	g := map[string][]string{
+		"shirt":    {"tie", "belt"},
+		"tie":      {"jacket"},
+		"pants":    {"shoes", "belt"},
+		"belt":     {"jacket"},
+		"socks":    {"shoes"},
+		"watch":    nil,
+		"trousers": {"pants"},
+	}
+
+	for i := 0; i < 20; i++ {
+		res, err := TopologicalSort(g)
+		if err != nil {
+			t.Fatalf("TopologicalSort: unexpected error %v", err)
+		}
+		if len(res) != 9 {
+			t.Fatalf("TopologicalSort: expected 9 nodes, got %v", res)
+		}
+
+		pos := make(map[string]int, len(res))
+		for i, k := range res {
+			pos[k] = i
+		}
+		for from, edges := range g {
+			for _, to := range edges {
+				if pos[from] > pos[to] {
+					t.Fatalf("TopologicalSort: %s should go before %s in %v", from, to, res)
+				}
+			}
+		}
+	}
+}
+
+func Test_TopologicalSort_Cycle(t *testing.T) {
+	g := map[string][]string{
+		"a": {"b"},
+		"b": {"c"},
+		"c": {"d", "b"},
+		"d": nil,
+	}
+
+	res, err := TopologicalSort(g)
+	if res != nil || !errors.Is(err, ErrCycle) {
+		t.Fatalf("TopologicalSort: expected ErrCycle, got %v, %v", res, err)
+	}
+
+	var cycleErr *CycleError[string]
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("TopologicalSort: expected CycleError, got %T", err)
+	}
+	sort.Strings(cycleErr.Cycle)
+	if !reflect.DeepEqual(cycleErr.Cycle, []string{"b", "c"}) {
+		t.Fatalf("TopologicalSort: unexpected cycle %v", cycleErr.Cycle)
+	}
+
+	selfLoop := map[int][]int{1: {1}}
+	if _, err := TopologicalSort(selfLoop); err == nil || err.Error() != "graph: cycle detected: 1 -> 1" {
+		t.Fatalf("TopologicalSort: unexpected error %v", err)
+	}
+}
+
+func Test_BFS_DFS(t *testing.T) {
+	g := map[int][]int{
+		1: {2, 3},
+		2: {4},
+		3: {4, 5},
+		4: {1},
+		6: {1},
+	}
+
+	var bfs, depths []int
+	BFS(g, 1, func(k int, depth int) bool {
+		bfs = append(bfs, k)
+		depths = append(depths, depth)
+		return true
+	})
+	if !reflect.DeepEqual(bfs, []int{1, 2, 3, 4, 5}) || !reflect.DeepEqual(depths, []int{0, 1, 1, 2, 2}) {
+		t.Fatalf("BFS: unexpected %v, %v", bfs, depths)
+	}
+
+	var dfs []int
+	DFS(g, 1, func(k int) bool {
+		dfs = append(dfs, k)
+		return true
+	})
+	if !reflect.DeepEqual(dfs, []int{1, 2, 4, 3, 5}) {
+		t.Fatalf("DFS: unexpected %v", dfs)
+	}
+
+	dfs = nil
+	DFS(g, 1, func(k int) bool {
+		dfs = append(dfs, k)
+		return k != 4
+	})
+	if !reflect.DeepEqual(dfs, []int{1, 2, 4}) {
+		t.Fatalf("DFS with stop: unexpected %v", dfs)
+	}
+}
+
+func Test_ConnectedComponents(t *testing.T) {
+	g := map[string][]string{
+		"a": {"b"},
+		"c": {"b"},
+		"d": {"e"},
+		"f": nil,
+	}
+
+	res := sortedComponents(ConnectedComponents(g))
+	expected := [][]string{{"a", "b", "c"}, {"d", "e"}, {"f"}}
+	if !reflect.DeepEqual(res, expected) {
+		t.Fatalf("ConnectedComponents: expected %v, got %v", expected, res)
+	}
+}
+
+func Test_StronglyConnectedComponents(t *testing.T) {
+	g := map[string][]string{
+		"a": {"b"},
+		"b": {"c", "e"},
+		"c": {"a", "d"},
+		"d": {"e"},
+		"e": {"d"},
+		"f": {"a"},
+	}
+
+	res := StronglyConnectedComponents(g)
+	order := map[string]int{}
+	for i, c := range res {
+		for _, k := range c {
+			order[k] = i
+		}
+	}
+	// reverse topological order: components with edges to the others go later
+	if order["f"] < order["a"] || order["a"] < order["d"] {
+		t.Fatalf("StronglyConnectedComponents: unexpected order %v", res)
+	}
+
+	expected := [][]string{{"a", "b", "c"}, {"d", "e"}, {"f"}}
+	if res := sortedComponents(res); !reflect.DeepEqual(res, expected) {
+		t.Fatalf("StronglyConnectedComponents: expected %v, got %v", expected, res)
+	}
+}
+
+func Test_ShortestPath(t *testing.T) {
+	g := map[string][]string{
+		"a": {"b", "c"},
+		"b": {"d"},
+		"c": {"d"},
+		"d": {"e"},
+	}
+
+	path, ok := ShortestPath(g, "a", "e")
+	if !ok || !reflect.DeepEqual(path, []string{"a", "b", "d", "e"}) {
+		t.Fatalf("ShortestPath: unexpected %v", path)
+	}
+	if path, ok := ShortestPath(g, "a", "a"); !ok || !reflect.DeepEqual(path, []string{"a"}) {
+		t.Fatalf("ShortestPath to itself: unexpected %v", path)
+	}
+	if path, ok := ShortestPath(g, "e", "a"); ok || path != nil {
+		t.Fatalf("ShortestPath: expected no path, got %v", path)
+	}
+}
+
+func Test_ShortestPathWeighted(t *testing.T) {
+	g := map[string][]string{
+		"a": {"b", "c"},
+		"b": {"d"},
+		"c": {"d"},
+		"d": {"e"},
+	}
+	weights := map[[2]string]float64{
+		{"a", "b"}: 5, {"a", "c"}: 1, {"b", "d"}: 1, {"c", "d"}: 2, {"d", "e"}: 0.5,
+	}
+
+	path, dist, ok := ShortestPathWeighted(g, "a", "e", func(from, to string) float64 { return weights[[2]string{from, to}] })
+	if !ok || dist != 3.5 || !reflect.DeepEqual(path, []string{"a", "c", "d", "e"}) {
+		t.Fatalf("ShortestPathWeighted: unexpected %v, %v", path, dist)
+	}
+
+	if _, _, ok := ShortestPathWeighted(g, "e", "a", func(string, string) int { return 1 }); ok {
+		t.Fatal("ShortestPathWeighted: expected no path")
+	}
+}
+
+func Test_Reverse(t *testing.T) {
+	deps := map[string]string{"api": "db", "worker": "db", "db": "disk"}
+	g := maps.Map(deps, func(v string, _ string, _ map[string]string) []string { return []string{v} })
+
+	res := Reverse(g)
+	for _, edges := range res {
+		sort.Strings(edges)
+	}
+	expected := map[string][]string{"db": {"api", "worker"}, "disk": {"db"}, "api": nil, "worker": nil}
+	if !reflect.DeepEqual(res, expected) {
+		t.Fatalf("Reverse: expected %v, got %v", expected, res)
+	}
+
+	if Reverse[int](nil) != nil {
+		t.Fatal("Reverse: nil in - nil out")
+	}
+}
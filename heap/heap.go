@@ -0,0 +1,159 @@
+// Package heap implements a generic binary heap and an indexed priority queue on top of it.
+package heap
+
+import "github.com/bullgare/funktional/constraints"
+
+// Heap is a binary heap ordered by a less function: Pop returns the smallest element.
+// It is not safe for concurrent use.
+type Heap[T any] struct {
+	items []T
+	less  func(a, b T) bool
+	// moved is called every time an element changes its position, it is used by PriorityQueue
+	moved func(item T, i int)
+}
+
+// New creates an empty heap ordered by a less function.
+func New[T any](less func(a, b T) bool) *Heap[T] {
+	return &Heap[T]{less: less}
+}
+
+// FromSlice creates a heap of the elements of a slice in O(n). The slice is copied.
+func FromSlice[T any](in []T, less func(a, b T) bool) *Heap[T] {
+	h := &Heap[T]{items: make([]T, len(in)), less: less}
+	copy(h.items, in)
+	for i := len(h.items)/2 - 1; i >= 0; i-- {
+		h.down(i)
+	}
+	return h
+}
+
+// MinHeap creates a heap of ordered elements returning the smallest one first.
+func MinHeap[T constraints.Ordered](items ...T) *Heap[T] {
+	return FromSlice(items, func(a, b T) bool { return a < b })
+}
+
+// MaxHeap creates a heap of ordered elements returning the largest one first.
+func MaxHeap[T constraints.Ordered](items ...T) *Heap[T] {
+	return FromSlice(items, func(a, b T) bool { return a > b })
+}
+
+// Len returns the number of elements.
+func (h *Heap[T]) Len() int {
+	return len(h.items)
+}
+
+// Push adds an element in O(log n).
+func (h *Heap[T]) Push(item T) {
+	h.items = append(h.items, item)
+	h.notify(len(h.items) - 1)
+	h.up(len(h.items) - 1)
+}
+
+// Pop removes and returns the smallest element in O(log n). If the heap is empty, it returns the zero value and false.
+func (h *Heap[T]) Pop() (T, bool) {
+	return h.Remove(0)
+}
+
+// Peek returns the smallest element without removing it. If the heap is empty, it returns the zero value and false.
+func (h *Heap[T]) Peek() (T, bool) {
+	if len(h.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.items[0], true
+}
+
+// Remove removes and returns the element at the index i (as in Slice) in O(log n).
+// If the index is out of range, it returns the zero value and false.
+func (h *Heap[T]) Remove(i int) (T, bool) {
+	if i < 0 || i >= len(h.items) {
+		var zero T
+		return zero, false
+	}
+
+	last := len(h.items) - 1
+	item := h.items[i]
+	if i != last {
+		h.swap(i, last)
+	}
+	var zero T
+	h.items[last] = zero
+	h.items = h.items[:last]
+
+	if i != last {
+		h.Fix(i)
+	}
+	return item, true
+}
+
+// Set replaces the element at the index i (as in Slice) and restores the heap order in O(log n).
+// It returns false if the index is out of range.
+func (h *Heap[T]) Set(i int, item T) bool {
+	if i < 0 || i >= len(h.items) {
+		return false
+	}
+	h.items[i] = item
+	h.notify(i)
+	h.Fix(i)
+	return true
+}
+
+// Fix restores the heap order after the element at the index i (as in Slice) was changed in place, in O(log n).
+func (h *Heap[T]) Fix(i int) {
+	if i < 0 || i >= len(h.items) {
+		return
+	}
+	if !h.down(i) {
+		h.up(i)
+	}
+}
+
+// Slice returns a copy of all the elements in the heap order (not sorted).
+func (h *Heap[T]) Slice() []T {
+	out := make([]T, len(h.items))
+	copy(out, h.items)
+	return out
+}
+
+func (h *Heap[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !h.less(h.items[i], h.items[parent]) {
+			return
+		}
+		h.swap(i, parent)
+		i = parent
+	}
+}
+
+// down moves the element i down and reports whether it was moved.
+func (h *Heap[T]) down(i int) bool {
+	start := i
+	for {
+		smallest := i
+		left, right := 2*i+1, 2*i+2
+		if left < len(h.items) && h.less(h.items[left], h.items[smallest]) {
+			smallest = left
+		}
+		if right < len(h.items) && h.less(h.items[right], h.items[smallest]) {
+			smallest = right
+		}
+		if smallest == i {
+			return i > start
+		}
+		h.swap(i, smallest)
+		i = smallest
+	}
+}
+
+func (h *Heap[T]) swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.notify(i)
+	h.notify(j)
+}
+
+func (h *Heap[T]) notify(i int) {
+	if h.moved != nil {
+		h.moved(h.items[i], i)
+	}
+}
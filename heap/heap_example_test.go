@@ -0,0 +1,56 @@
+package heap
+
+import "fmt"
+
+func ExampleMinHeap() {
+	h := MinHeap(5, 2, 8, 1)
+	h.Push(3)
+
+	for h.Len() > 0 {
+		v, _ := h.Pop()
+		fmt.Print(v, " ")
+	}
+	fmt.Println()
+
+	// Output:
+	// 1 2 3 5 8
+}
+
+func ExampleNew() {
+	// top 3 longest words: keep a min-heap of size 3 by length
+	h := New(func(a, b string) bool { return len(a) < len(b) })
+	for _, w := range []string{"a", "abcd", "ab", "abcdef", "abc", "abcde"} {
+		h.Push(w)
+		if h.Len() > 3 {
+			h.Pop()
+		}
+	}
+
+	for h.Len() > 0 {
+		v, _ := h.Pop()
+		fmt.Println(v)
+	}
+
+	// Output:
+	// abcd
+	// abcde
+	// abcdef
+}
+
+func ExamplePriorityQueue() {
+	pq := NewPriorityQueue[string, int](func(a, b int) bool { return a < b })
+	pq.Push("write docs", 3)
+	pq.Push("fix bug", 2)
+	pq.Push("release", 5)
+	pq.UpdatePriority("release", 1)
+
+	for pq.Len() > 0 {
+		task, priority, _ := pq.Pop()
+		fmt.Println(priority, task)
+	}
+
+	// Output:
+	// 1 release
+	// 2 fix bug
+	// 3 write docs
+}
@@ -0,0 +1,161 @@
+package heap
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func popAll[T any](h *Heap[T]) []T {
+	var out []T
+	for h.Len() > 0 {
+		v, _ := h.Pop()
+		out = append(out, v)
+	}
+	return out
+}
+
+func Test_Heap(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for _, n := range []int{0, 1, 2, 3, 10, 100, 1000} {
+		in := make([]int, n)
+		for i := range in {
+			in[i] = r.Intn(n + 1)
+		}
+		sorted := append([]int(nil), in...)
+		sort.Ints(sorted)
+
+		h := New(func(a, b int) bool { return a < b })
+		for _, v := range in {
+			h.Push(v)
+		}
+		if res := popAll(h); !reflect.DeepEqual(res, sorted) && n > 0 {
+			t.Fatalf("Push/Pop %d: expected %v, got %v", n, sorted, res)
+		}
+
+		if res := popAll(MinHeap(in...)); !reflect.DeepEqual(res, sorted) && n > 0 {
+			t.Fatalf("MinHeap %d: expected %v, got %v", n, sorted, res)
+		}
+
+		sort.Sort(sort.Reverse(sort.IntSlice(sorted)))
+		if res := popAll(MaxHeap(in...)); !reflect.DeepEqual(res, sorted) && n > 0 {
+			t.Fatalf("MaxHeap %d: expected %v, got %v", n, sorted, res)
+		}
+	}
+}
+
+func Test_Heap_Empty(t *testing.T) {
+	h := MinHeap[int]()
+
+	if _, ok := h.Pop(); ok {
+		t.Fatal("Pop: expected no elements")
+	}
+	if _, ok := h.Peek(); ok {
+		t.Fatal("Peek: expected no elements")
+	}
+	if _, ok := h.Remove(0); ok {
+		t.Fatal("Remove: expected no elements")
+	}
+}
+
+func Test_Heap_FromSliceDoesNotMutate(t *testing.T) {
+	in := []int{5, 4, 3, 2, 1}
+	h := MinHeap(in...)
+	h.Push(0)
+
+	if !reflect.DeepEqual(in, []int{5, 4, 3, 2, 1}) {
+		t.Fatalf("MinHeap: original slice was changed: %v", in)
+	}
+	if v, _ := h.Peek(); v != 0 {
+		t.Fatalf("Peek: expected 0, got %d", v)
+	}
+}
+
+func Test_Heap_FixAndRemove(t *testing.T) {
+	type task struct {
+		name     string
+		priority int
+	}
+	tasks := []*task{{"a", 5}, {"b", 3}, {"c", 8}, {"d", 1}, {"e", 4}}
+	h := FromSlice(tasks, func(a, b *task) bool { return a.priority < b.priority })
+
+	// change the priority in place and fix the heap
+	for i, tk := range h.Slice() {
+		if tk.name == "c" {
+			tk.priority = 0
+			h.Fix(i)
+		}
+	}
+	for i, tk := range h.Slice() {
+		if tk.name == "b" {
+			if removed, ok := h.Remove(i); !ok || removed.name != "b" {
+				t.Fatalf("Remove: expected b, got %v", removed)
+			}
+		}
+	}
+
+	var names []string
+	for _, tk := range popAll(h) {
+		names = append(names, tk.name)
+	}
+	if expected := []string{"c", "d", "e", "a"}; !reflect.DeepEqual(names, expected) {
+		t.Fatalf("Fix/Remove: expected %v, got %v", expected, names)
+	}
+}
+
+func Test_PriorityQueue(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	pq := NewPriorityQueue[int, int](func(a, b int) bool { return a < b })
+	expected := map[int]int{}
+
+	for step := 0; step < 5000; step++ {
+		key := r.Intn(100)
+		switch op := r.Intn(4); op {
+		case 0:
+			p := r.Intn(1000)
+			pq.Push(key, p)
+			expected[key] = p
+		case 1:
+			p := r.Intn(1000)
+			_, exists := expected[key]
+			if pq.UpdatePriority(key, p) != exists {
+				t.Fatalf("UpdatePriority(%d): unexpected result", key)
+			}
+			if exists {
+				expected[key] = p
+			}
+		case 2:
+			p, ok := pq.Remove(key)
+			if e, exists := expected[key]; ok != exists || p != e {
+				t.Fatalf("Remove(%d): expected %d, %v, got %d, %v", key, e, exists, p, ok)
+			}
+			delete(expected, key)
+		case 3:
+			k, p, ok := pq.Pop()
+			if ok != (len(expected) > 0) {
+				t.Fatalf("Pop: unexpected ok %v", ok)
+			}
+			if !ok {
+				continue
+			}
+			for _, other := range expected {
+				if other < p {
+					t.Fatalf("Pop: got priority %d while %d is in the queue", p, other)
+				}
+			}
+			if expected[k] != p {
+				t.Fatalf("Pop: key %d has priority %d, expected %d", k, p, expected[k])
+			}
+			delete(expected, k)
+		}
+
+		if pq.Len() != len(expected) {
+			t.Fatalf("Len: expected %d, got %d", len(expected), pq.Len())
+		}
+		if p, ok := pq.Priority(key); ok != pq.Contains(key) || (ok && p != expected[key]) {
+			t.Fatalf("Priority(%d): expected %d, got %d", key, expected[key], p)
+		}
+	}
+}
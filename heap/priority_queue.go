@@ -0,0 +1,88 @@
+package heap
+
+type pqItem[K comparable, P any] struct {
+	key      K
+	priority P
+}
+
+// PriorityQueue is a heap of unique keys with priorities, which can be updated or removed by a key in O(log n).
+// It is not safe for concurrent use.
+type PriorityQueue[K comparable, P any] struct {
+	heap  *Heap[pqItem[K, P]]
+	index map[K]int
+}
+
+// NewPriorityQueue creates an empty priority queue. Pop returns the key with the smallest priority by less.
+func NewPriorityQueue[K comparable, P any](less func(a, b P) bool) *PriorityQueue[K, P] {
+	pq := &PriorityQueue[K, P]{index: make(map[K]int)}
+	pq.heap = New(func(a, b pqItem[K, P]) bool { return less(a.priority, b.priority) })
+	pq.heap.moved = func(item pqItem[K, P], i int) {
+		pq.index[item.key] = i
+	}
+	return pq
+}
+
+// Len returns the number of keys.
+func (pq *PriorityQueue[K, P]) Len() int {
+	return pq.heap.Len()
+}
+
+// Push adds a key with a priority. If the key is already in the queue, its priority is updated.
+func (pq *PriorityQueue[K, P]) Push(key K, priority P) {
+	if pq.UpdatePriority(key, priority) {
+		return
+	}
+	pq.heap.Push(pqItem[K, P]{key: key, priority: priority})
+}
+
+// Pop removes and returns the key with the smallest priority. If the queue is empty, ok is false.
+func (pq *PriorityQueue[K, P]) Pop() (key K, priority P, ok bool) {
+	item, ok := pq.heap.Pop()
+	if ok {
+		delete(pq.index, item.key)
+	}
+	return item.key, item.priority, ok
+}
+
+// Peek returns the key with the smallest priority without removing it. If the queue is empty, ok is false.
+func (pq *PriorityQueue[K, P]) Peek() (key K, priority P, ok bool) {
+	item, ok := pq.heap.Peek()
+	return item.key, item.priority, ok
+}
+
+// Priority returns the priority of a key and whether the key is in the queue.
+func (pq *PriorityQueue[K, P]) Priority(key K) (P, bool) {
+	i, ok := pq.index[key]
+	if !ok {
+		var zero P
+		return zero, false
+	}
+	return pq.heap.items[i].priority, true
+}
+
+// Contains reports whether the key is in the queue.
+func (pq *PriorityQueue[K, P]) Contains(key K) bool {
+	_, ok := pq.index[key]
+	return ok
+}
+
+// UpdatePriority changes the priority of a key. It returns false if there is no such key.
+func (pq *PriorityQueue[K, P]) UpdatePriority(key K, priority P) bool {
+	i, ok := pq.index[key]
+	if !ok {
+		return false
+	}
+	return pq.heap.Set(i, pqItem[K, P]{key: key, priority: priority})
+}
+
+// Remove removes a key and returns its priority. If there is no such key, it returns the zero value and false.
+func (pq *PriorityQueue[K, P]) Remove(key K) (P, bool) {
+	i, ok := pq.index[key]
+	if !ok {
+		var zero P
+		return zero, false
+	}
+	item, _ := pq.heap.Remove(i)
+	delete(pq.index, key)
+	return item.priority, true
+}
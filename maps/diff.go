@@ -0,0 +1,110 @@
+package maps
+
+import "reflect"
+
+// Change holds an old and a new value of a changed key.
+type Change[T any] struct {
+	Old T
+	New T
+}
+
+// Difference is a result of Diff. Every key of both maps is in exactly one of its fields.
+type Difference[K comparable, T any] struct {
+	// Added keys are only in the second map.
+	Added map[K]T
+	// Removed keys are only in the first map.
+	Removed map[K]T
+	// Changed keys are in both maps with different values.
+	Changed map[K]Change[T]
+	// Unchanged keys are in both maps with equal values.
+	Unchanged map[K]T
+}
+
+// IsEmpty reports whether there are no added, removed or changed keys.
+func (d Difference[K, T]) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Diff compares two maps and returns keys which were added, removed, changed or left unchanged on the way from a to b.
+// Values are compared with eq. All the fields of the result are non-nil maps.
+func Diff[T any, K comparable](a, b map[K]T, eq func(T, T) bool) Difference[K, T] {
+	res := Difference[K, T]{
+		Added:     make(map[K]T),
+		Removed:   make(map[K]T),
+		Changed:   make(map[K]Change[T]),
+		Unchanged: make(map[K]T),
+	}
+
+	for k, old := range a {
+		v, ok := b[k]
+		switch {
+		case !ok:
+			res.Removed[k] = old
+		case eq(old, v):
+			res.Unchanged[k] = v
+		default:
+			res.Changed[k] = Change[T]{Old: old, New: v}
+		}
+	}
+
+	for k, v := range b {
+		if _, ok := a[k]; !ok {
+			res.Added[k] = v
+		}
+	}
+
+	return res
+}
+
+// DeepDiff compares two trees of nested map[string]any.
+// Both trees are flattened with Flatten (using separator and opts), so the keys of the result are paths to the leaves.
+// Leaves are compared with reflect.DeepEqual.
+func DeepDiff(a, b map[string]any, separator string, opts ...FlattenOption) (Difference[string, any], error) {
+	flatA, err := Flatten(a, separator, opts...)
+	if err != nil {
+		return Difference[string, any]{}, err
+	}
+	flatB, err := Flatten(b, separator, opts...)
+	if err != nil {
+		return Difference[string, any]{}, err
+	}
+
+	return Diff(flatA, flatB, func(x, y any) bool { return reflect.DeepEqual(x, y) }), nil
+}
+
+// Patch applies a diff to a map: removed keys are deleted, added and changed keys are set to the new values.
+// So Patch(a, Diff(a, b, eq)) gives a map equal to b. Original map stays untouched.
+// Old values of the diff are not checked against the map.
+func Patch[T any, K comparable](in map[K]T, diff Difference[K, T]) map[K]T {
+	if in == nil && len(diff.Added) == 0 && len(diff.Changed) == 0 {
+		return nil
+	}
+
+	out := Copy(in)
+	if out == nil {
+		out = make(map[K]T, len(diff.Added))
+	}
+
+	for k := range diff.Removed {
+		delete(out, k)
+	}
+	for k, v := range diff.Added {
+		out[k] = v
+	}
+	for k, change := range diff.Changed {
+		out[k] = change.New
+	}
+
+	return out
+}
+
+// DeepPatch applies a diff made by DeepDiff to a tree of nested map[string]any.
+// The same separator and opts as for DeepDiff should be used.
+func DeepPatch(in map[string]any, diff Difference[string, any], separator string, opts ...FlattenOption) (map[string]any, error) {
+	flat, err := Flatten(in, separator, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return Unflatten(Patch(flat, diff), separator, opts...)
+}
@@ -0,0 +1,169 @@
+package maps
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_Diff(t *testing.T) {
+	eq := func(a, b int) bool { return a == b }
+
+	tt := []struct {
+		name     string
+		a        map[string]int
+		b        map[string]int
+		expected Difference[string, int]
+	}{
+		{
+			name: "all kinds of changes",
+			a:    map[string]int{"a": 1, "b": 2, "c": 3},
+			b:    map[string]int{"b": 2, "c": 4, "d": 5},
+			expected: Difference[string, int]{
+				Added:     map[string]int{"d": 5},
+				Removed:   map[string]int{"a": 1},
+				Changed:   map[string]Change[int]{"c": {Old: 3, New: 4}},
+				Unchanged: map[string]int{"b": 2},
+			},
+		},
+		{
+			name: "nil to map - everything is added",
+			a:    nil,
+			b:    map[string]int{"a": 1},
+			expected: Difference[string, int]{
+				Added:     map[string]int{"a": 1},
+				Removed:   map[string]int{},
+				Changed:   map[string]Change[int]{},
+				Unchanged: map[string]int{},
+			},
+		},
+		{
+			name: "nil and nil - empty diff",
+			a:    nil,
+			b:    nil,
+			expected: Difference[string, int]{
+				Added:     map[string]int{},
+				Removed:   map[string]int{},
+				Changed:   map[string]Change[int]{},
+				Unchanged: map[string]int{},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			res := Diff(tc.a, tc.b, eq)
+
+			if !reflect.DeepEqual(res, tc.expected) {
+				t.Fatalf(`Diff %s: expected
+				%#v, got
+				%#v`, tc.name, tc.expected, res)
+			}
+
+			patched := Patch(tc.a, res)
+			if !reflect.DeepEqual(patched, tc.b) {
+				t.Fatalf(`Patch %s: expected
+				%#v, got
+				%#v`, tc.name, tc.b, patched)
+			}
+		})
+	}
+}
+
+func Test_Diff_CustomEq(t *testing.T) {
+	a := map[string]float64{"a": 1.0, "b": 2.0}
+	b := map[string]float64{"a": 1.05, "b": 3.0}
+
+	res := Diff(a, b, func(x, y float64) bool { return x-y < 0.1 && y-x < 0.1 })
+
+	if !reflect.DeepEqual(res.Unchanged, map[string]float64{"a": 1.05}) {
+		t.Fatalf("Diff: unexpected Unchanged %#v", res.Unchanged)
+	}
+	if !reflect.DeepEqual(res.Changed, map[string]Change[float64]{"b": {Old: 2.0, New: 3.0}}) {
+		t.Fatalf("Diff: unexpected Changed %#v", res.Changed)
+	}
+	if res.IsEmpty() {
+		t.Fatal("Diff: expected non-empty diff")
+	}
+}
+
+func Test_Patch_DoesNotMutate(t *testing.T) {
+	a := map[string]int{"a": 1, "b": 2}
+	b := map[string]int{"b": 3}
+
+	res := Patch(a, Diff(a, b, func(x, y int) bool { return x == y }))
+
+	if !reflect.DeepEqual(res, b) {
+		t.Fatalf("Patch: expected %#v, got %#v", b, res)
+	}
+	if !reflect.DeepEqual(a, map[string]int{"a": 1, "b": 2}) {
+		t.Fatalf("Patch: original map was changed: %#v", a)
+	}
+}
+
+func Test_DeepDiff(t *testing.T) {
+	a := map[string]any{
+		"server": map[string]any{"host": "localhost", "port": 80},
+		"labels": []any{"a", "b"},
+		"old":    map[string]any{"x": 1},
+	}
+	b := map[string]any{
+		"server": map[string]any{"host": "localhost", "port": 8080, "tls": true},
+		"labels": []any{"a", "c"},
+		"old":    2,
+	}
+
+	res, err := DeepDiff(a, b, ".")
+	if err != nil {
+		t.Fatalf("DeepDiff: unexpected error %v", err)
+	}
+
+	expected := Difference[string, any]{
+		Added:   map[string]any{"server.tls": true, "old": 2},
+		Removed: map[string]any{"old.x": 1},
+		Changed: map[string]Change[any]{
+			"server.port": {Old: 80, New: 8080},
+			"labels":      {Old: []any{"a", "b"}, New: []any{"a", "c"}},
+		},
+		Unchanged: map[string]any{"server.host": "localhost"},
+	}
+	if !reflect.DeepEqual(res, expected) {
+		t.Fatalf(`DeepDiff: expected
+				%#v, got
+				%#v`, expected, res)
+	}
+
+	patched, err := DeepPatch(a, res, ".")
+	if err != nil {
+		t.Fatalf("DeepPatch: unexpected error %v", err)
+	}
+	if !reflect.DeepEqual(patched, b) {
+		t.Fatalf(`DeepPatch: expected
+				%#v, got
+				%#v`, b, patched)
+	}
+}
+
+func Test_DeepDiff_SliceIndexes(t *testing.T) {
+	a := map[string]any{"labels": []any{"a", "b"}}
+	b := map[string]any{"labels": []any{"a", "c", "d"}}
+	opts := []FlattenOption{WithSliceIndexNotation(SliceIndexBrackets)}
+
+	res, err := DeepDiff(a, b, ".", opts...)
+	if err != nil {
+		t.Fatalf("DeepDiff: unexpected error %v", err)
+	}
+	if !reflect.DeepEqual(res.Changed, map[string]Change[any]{"labels[1]": {Old: "b", New: "c"}}) {
+		t.Fatalf("DeepDiff: unexpected Changed %#v", res.Changed)
+	}
+	if !reflect.DeepEqual(res.Added, map[string]any{"labels[2]": "d"}) {
+		t.Fatalf("DeepDiff: unexpected Added %#v", res.Added)
+	}
+
+	patched, err := DeepPatch(a, res, ".", opts...)
+	if err != nil {
+		t.Fatalf("DeepPatch: unexpected error %v", err)
+	}
+	if !reflect.DeepEqual(patched, b) {
+		t.Fatalf("DeepPatch: expected %#v, got %#v", b, patched)
+	}
+}
@@ -0,0 +1,332 @@
+package maps
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrKeyCollision is returned by Flatten and Unflatten (with WithCollisionError option)
+// when two different paths end up in the same key.
+var ErrKeyCollision = errors.New("maps: key collision")
+
+// SliceIndexNotation defines how Flatten and Unflatten treat []any values.
+type SliceIndexNotation int
+
+const (
+	// SliceIndexNone keeps slices as they are (default).
+	SliceIndexNone SliceIndexNotation = iota
+	// SliceIndexDot flattens slices into keys like "a.0.b".
+	SliceIndexDot
+	// SliceIndexBrackets flattens slices into keys like "a[0].b".
+	SliceIndexBrackets
+)
+
+// FlattenOption configures Flatten and Unflatten.
+type FlattenOption func(*flattenConfig)
+
+type flattenConfig struct {
+	notation       SliceIndexNotation
+	maxDepth       int
+	errOnCollision bool
+}
+
+// WithSliceIndexNotation sets how slices are flattened (and restored back).
+func WithSliceIndexNotation(notation SliceIndexNotation) FlattenOption {
+	return func(c *flattenConfig) {
+		c.notation = notation
+	}
+}
+
+// WithMaxDepth limits the number of segments in a flattened key.
+// Values deeper than that are kept as they are. Zero or negative depth means no limit.
+func WithMaxDepth(depth int) FlattenOption {
+	return func(c *flattenConfig) {
+		c.maxDepth = depth
+	}
+}
+
+// WithCollisionError makes Flatten and Unflatten return ErrKeyCollision instead of silently overwriting values.
+func WithCollisionError() FlattenOption {
+	return func(c *flattenConfig) {
+		c.errOnCollision = true
+	}
+}
+
+func newFlattenConfig(opts []FlattenOption) flattenConfig {
+	var c flattenConfig
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// Flatten turns a tree of nested map[string]any (and, optionally, []any) into a flat map with keys joined by separator.
+// Empty nested maps and slices are kept as values, so the result can be turned back with Unflatten.
+// Keys are processed in sorted order, so without WithCollisionError option the last key in that order wins.
+func Flatten(in map[string]any, separator string, opts ...FlattenOption) (map[string]any, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	c := newFlattenConfig(opts)
+	out := make(map[string]any, len(in))
+	if err := flattenMap(out, in, "", 0, separator, c); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func flattenMap(out map[string]any, in map[string]any, prefix string, depth int, separator string, c flattenConfig) error {
+	keys := Keys(in)
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		key := k
+		if depth > 0 {
+			key = prefix + separator + k
+		}
+		if err := flattenValue(out, in[k], key, depth+1, separator, c); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func flattenValue(out map[string]any, v any, key string, depth int, separator string, c flattenConfig) error {
+	if c.maxDepth <= 0 || depth < c.maxDepth {
+		switch nested := v.(type) {
+		case map[string]any:
+			if len(nested) > 0 {
+				return flattenMap(out, nested, key, depth, separator, c)
+			}
+		case []any:
+			if len(nested) > 0 && c.notation != SliceIndexNone {
+				for i, elem := range nested {
+					if err := flattenValue(out, elem, indexKey(key, i, separator, c.notation), depth+1, separator, c); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+		}
+	}
+
+	if _, ok := out[key]; ok && c.errOnCollision {
+		return fmt.Errorf("%w: %q", ErrKeyCollision, key)
+	}
+	out[key] = v
+
+	return nil
+}
+
+func indexKey(prefix string, i int, separator string, notation SliceIndexNotation) string {
+	if notation == SliceIndexBrackets {
+		return prefix + "[" + strconv.Itoa(i) + "]"
+	}
+	return prefix + separator + strconv.Itoa(i)
+}
+
+// Unflatten turns a flat map with keys joined by separator back into a tree of nested map[string]any.
+// With SliceIndexDot notation a nested map is turned into []any only if its keys are exactly 0..n-1,
+// with SliceIndexBrackets notation "[i]" segments make a []any if their indexes are exactly 0..n-1 as well
+// (sparse or huge indexes stay map keys, so a single key cannot make a huge slice).
+// If a key is both a value and a parent of other keys ("a" and "a.b"), the nested value wins,
+// unless WithCollisionError option is given.
+func Unflatten(in map[string]any, separator string, opts ...FlattenOption) (map[string]any, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	c := newFlattenConfig(opts)
+	root := &unflattenNode{}
+
+	keys := Keys(in)
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		node := root
+		for _, seg := range splitKey(k, separator, c) {
+			var err error
+			if node, err = node.child(seg, c); err != nil {
+				return nil, fmt.Errorf("%w: %q", err, k)
+			}
+		}
+		if c.errOnCollision && (node.hasValue || len(node.children) > 0 || len(node.indexes) > 0) {
+			return nil, fmt.Errorf("%w: %q", ErrKeyCollision, k)
+		}
+		node.value, node.hasValue = in[k], true
+	}
+
+	// the top level is always a map
+	return root.buildMap(c), nil
+}
+
+type keySegment struct {
+	name    string
+	index   int
+	isIndex bool
+}
+
+func splitKey(key, separator string, c flattenConfig) []keySegment {
+	var parts []string
+	if separator == "" {
+		parts = []string{key}
+	} else {
+		parts = strings.Split(key, separator)
+	}
+
+	segs := make([]keySegment, 0, len(parts))
+	offset := 0
+	for i, part := range parts {
+		if c.maxDepth > 0 && len(segs) >= c.maxDepth-1 {
+			// the rest of the key is a single segment
+			segs = append(segs, keySegment{name: key[offset:]})
+			return segs
+		}
+
+		partSegs := []keySegment{{name: part}}
+		if c.notation == SliceIndexBrackets {
+			partSegs = splitBrackets(part)
+		}
+		if c.maxDepth > 0 && len(segs)+len(partSegs) > c.maxDepth {
+			segs = append(segs, keySegment{name: key[offset:]})
+			return segs
+		}
+		segs = append(segs, partSegs...)
+
+		offset += len(part)
+		if i < len(parts)-1 {
+			offset += len(separator)
+		}
+	}
+
+	return segs
+}
+
+// splitBrackets splits "a[0][1]" into a name and indexes. Anything not matching this pattern is a plain name.
+func splitBrackets(part string) []keySegment {
+	open := strings.IndexByte(part, '[')
+	if open < 0 || !strings.HasSuffix(part, "]") {
+		return []keySegment{{name: part}}
+	}
+
+	segs := []keySegment{{name: part[:open]}}
+	for _, idx := range strings.Split(part[open+1:len(part)-1], "][") {
+		i, err := strconv.Atoi(idx)
+		if err != nil || i < 0 {
+			return []keySegment{{name: part}}
+		}
+		segs = append(segs, keySegment{index: i, isIndex: true})
+	}
+	if open == 0 {
+		// key starts with an index, no name segment
+		return segs[1:]
+	}
+
+	return segs
+}
+
+type unflattenNode struct {
+	value    any
+	hasValue bool
+	children map[string]*unflattenNode
+	indexes  map[int]*unflattenNode
+}
+
+func (n *unflattenNode) child(seg keySegment, c flattenConfig) (*unflattenNode, error) {
+	if n.hasValue && c.errOnCollision {
+		return nil, ErrKeyCollision
+	}
+
+	if seg.isIndex {
+		if len(n.children) > 0 && c.errOnCollision {
+			return nil, ErrKeyCollision
+		}
+		if n.indexes == nil {
+			n.indexes = make(map[int]*unflattenNode)
+		}
+		if next, ok := n.indexes[seg.index]; ok {
+			return next, nil
+		}
+		next := &unflattenNode{}
+		n.indexes[seg.index] = next
+		return next, nil
+	}
+
+	if len(n.indexes) > 0 && c.errOnCollision {
+		return nil, ErrKeyCollision
+	}
+	if n.children == nil {
+		n.children = make(map[string]*unflattenNode)
+	}
+	if next, ok := n.children[seg.name]; ok {
+		return next, nil
+	}
+	next := &unflattenNode{}
+	n.children[seg.name] = next
+	return next, nil
+}
+
+func (n *unflattenNode) build(c flattenConfig) any {
+	if len(n.children) == 0 && len(n.indexes) == 0 && n.hasValue {
+		return n.value
+	}
+
+	if len(n.children) == 0 && len(n.indexes) > 0 {
+		if out, ok := n.buildIndexSlice(c); ok {
+			return out
+		}
+	}
+
+	if c.notation == SliceIndexDot && len(n.indexes) == 0 {
+		if out, ok := n.buildDotSlice(c); ok {
+			return out
+		}
+	}
+
+	return n.buildMap(c)
+}
+
+func (n *unflattenNode) buildMap(c flattenConfig) map[string]any {
+	out := make(map[string]any, len(n.children)+len(n.indexes))
+	for k, child := range n.children {
+		out[k] = child.build(c)
+	}
+	for i, child := range n.indexes {
+		out[strconv.Itoa(i)] = child.build(c)
+	}
+	return out
+}
+
+func (n *unflattenNode) buildIndexSlice(c flattenConfig) ([]any, bool) {
+	out := make([]any, len(n.indexes))
+	for i := range n.indexes {
+		if i >= len(out) {
+			return nil, false
+		}
+	}
+	for i, child := range n.indexes {
+		out[i] = child.build(c)
+	}
+	return out, true
+}
+
+func (n *unflattenNode) buildDotSlice(c flattenConfig) ([]any, bool) {
+	out := make([]any, len(n.children))
+	for k := range n.children {
+		i, err := strconv.Atoi(k)
+		if err != nil || i < 0 || i >= len(out) || strconv.Itoa(i) != k {
+			return nil, false
+		}
+	}
+	for k, child := range n.children {
+		i, _ := strconv.Atoi(k)
+		out[i] = child.build(c)
+	}
+	return out, true
+}
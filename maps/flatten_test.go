@@ -0,0 +1,231 @@
+package maps
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func Test_Flatten(t *testing.T) {
+	tt := []struct {
+		name     string
+		in       map[string]any
+		opts     []FlattenOption
+		expected map[string]any
+		err      error
+	}{
+		{
+			name: "nested maps",
+			in: map[string]any{
+				"a": map[string]any{"b": 1, "c": map[string]any{"d": "x"}},
+				"e": true,
+			},
+			expected: map[string]any{"a.b": 1, "a.c.d": "x", "e": true},
+		},
+		{
+			name:     "empty nested map is kept",
+			in:       map[string]any{"a": map[string]any{}},
+			expected: map[string]any{"a": map[string]any{}},
+		},
+		{
+			name:     "slices are kept by default",
+			in:       map[string]any{"a": []any{1, 2}},
+			expected: map[string]any{"a": []any{1, 2}},
+		},
+		{
+			name:     "slices with dot notation",
+			in:       map[string]any{"a": []any{1, map[string]any{"b": 2}}},
+			opts:     []FlattenOption{WithSliceIndexNotation(SliceIndexDot)},
+			expected: map[string]any{"a.0": 1, "a.1.b": 2},
+		},
+		{
+			name:     "slices with brackets notation",
+			in:       map[string]any{"a": []any{1, []any{2, map[string]any{"b": 3}}}},
+			opts:     []FlattenOption{WithSliceIndexNotation(SliceIndexBrackets)},
+			expected: map[string]any{"a[0]": 1, "a[1][0]": 2, "a[1][1].b": 3},
+		},
+		{
+			name:     "max depth",
+			in:       map[string]any{"a": map[string]any{"b": map[string]any{"c": 1}}, "d": 2},
+			opts:     []FlattenOption{WithMaxDepth(2)},
+			expected: map[string]any{"a.b": map[string]any{"c": 1}, "d": 2},
+		},
+		{
+			name:     "collision - last sorted key wins",
+			in:       map[string]any{"a": map[string]any{"b": 1}, "a.b": 2},
+			expected: map[string]any{"a.b": 2},
+		},
+		{
+			name: "collision - error",
+			in:   map[string]any{"a": map[string]any{"b": 1}, "a.b": 2},
+			opts: []FlattenOption{WithCollisionError()},
+			err:  ErrKeyCollision,
+		},
+		{
+			name:     "nil in - nil out",
+			in:       nil,
+			expected: nil,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := Flatten(tc.in, ".", tc.opts...)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("Flatten %s: expected error %v, got %v", tc.name, tc.err, err)
+			}
+
+			if !reflect.DeepEqual(res, tc.expected) {
+				t.Fatalf(`Flatten %s: expected
+				%#v, got
+				%#v`, tc.name, tc.expected, res)
+			}
+		})
+	}
+}
+
+func Test_Unflatten(t *testing.T) {
+	tt := []struct {
+		name     string
+		in       map[string]any
+		opts     []FlattenOption
+		expected map[string]any
+		err      error
+	}{
+		{
+			name: "nested maps",
+			in:   map[string]any{"a.b": 1, "a.c.d": "x", "e": true},
+			expected: map[string]any{
+				"a": map[string]any{"b": 1, "c": map[string]any{"d": "x"}},
+				"e": true,
+			},
+		},
+		{
+			name:     "numeric keys stay map keys by default",
+			in:       map[string]any{"a.0": 1, "a.1": 2},
+			expected: map[string]any{"a": map[string]any{"0": 1, "1": 2}},
+		},
+		{
+			name:     "dot notation",
+			in:       map[string]any{"a.0": 1, "a.1.b": 2},
+			opts:     []FlattenOption{WithSliceIndexNotation(SliceIndexDot)},
+			expected: map[string]any{"a": []any{1, map[string]any{"b": 2}}},
+		},
+		{
+			name:     "dot notation - not a sequence stays a map",
+			in:       map[string]any{"a.0": 1, "a.2": 2},
+			opts:     []FlattenOption{WithSliceIndexNotation(SliceIndexDot)},
+			expected: map[string]any{"a": map[string]any{"0": 1, "2": 2}},
+		},
+		{
+			name:     "dot notation - top level is always a map",
+			in:       map[string]any{"0": 1},
+			opts:     []FlattenOption{WithSliceIndexNotation(SliceIndexDot)},
+			expected: map[string]any{"0": 1},
+		},
+		{
+			name:     "brackets notation",
+			in:       map[string]any{"a[0]": 1, "a[1][0].b": 3},
+			opts:     []FlattenOption{WithSliceIndexNotation(SliceIndexBrackets)},
+			expected: map[string]any{"a": []any{1, []any{map[string]any{"b": 3}}}},
+		},
+		{
+			name:     "brackets notation - sparse indexes stay map keys",
+			in:       map[string]any{"a[0]": 1, "a[2][1].b": 3},
+			opts:     []FlattenOption{WithSliceIndexNotation(SliceIndexBrackets)},
+			expected: map[string]any{"a": map[string]any{"0": 1, "2": map[string]any{"1": map[string]any{"b": 3}}}},
+		},
+		{
+			name:     "brackets notation - huge indexes stay map keys",
+			in:       map[string]any{"a[99999999999999]": 1, "b[100000000]": 2},
+			opts:     []FlattenOption{WithSliceIndexNotation(SliceIndexBrackets)},
+			expected: map[string]any{"a": map[string]any{"99999999999999": 1}, "b": map[string]any{"100000000": 2}},
+		},
+		{
+			name:     "brackets notation - malformed index is a plain key",
+			in:       map[string]any{"a[x]": 1},
+			opts:     []FlattenOption{WithSliceIndexNotation(SliceIndexBrackets)},
+			expected: map[string]any{"a[x]": 1},
+		},
+		{
+			name:     "max depth",
+			in:       map[string]any{"a.b.c.d": 1},
+			opts:     []FlattenOption{WithMaxDepth(2)},
+			expected: map[string]any{"a": map[string]any{"b.c.d": 1}},
+		},
+		{
+			name:     "collision - nested value wins",
+			in:       map[string]any{"a": 1, "a.b": 2},
+			expected: map[string]any{"a": map[string]any{"b": 2}},
+		},
+		{
+			name: "collision - error",
+			in:   map[string]any{"a": 1, "a.b": 2},
+			opts: []FlattenOption{WithCollisionError()},
+			err:  ErrKeyCollision,
+		},
+		{
+			name: "collision between index and key - error",
+			in:   map[string]any{"a[0]": 1, "a.b": 2},
+			opts: []FlattenOption{WithSliceIndexNotation(SliceIndexBrackets), WithCollisionError()},
+			err:  ErrKeyCollision,
+		},
+		{
+			name:     "nil in - nil out",
+			in:       nil,
+			expected: nil,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := Unflatten(tc.in, ".", tc.opts...)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("Unflatten %s: expected error %v, got %v", tc.name, tc.err, err)
+			}
+
+			if !reflect.DeepEqual(res, tc.expected) {
+				t.Fatalf(`Unflatten %s: expected
+				%#v, got
+				%#v`, tc.name, tc.expected, res)
+			}
+		})
+	}
+}
+
+func Test_FlattenUnflatten_RoundTrip(t *testing.T) {
+	in := map[string]any{
+		"server": map[string]any{
+			"host":  "localhost",
+			"ports": []any{80, 443},
+			"tls":   map[string]any{},
+		},
+		"labels": []any{
+			map[string]any{"name": "a"},
+			[]any{},
+		},
+		"debug": false,
+	}
+
+	for _, notation := range []SliceIndexNotation{SliceIndexNone, SliceIndexDot, SliceIndexBrackets} {
+		for _, depth := range []int{0, 1, 2, 3} {
+			opts := []FlattenOption{WithSliceIndexNotation(notation), WithMaxDepth(depth), WithCollisionError()}
+
+			flat, err := Flatten(in, "/", opts...)
+			if err != nil {
+				t.Fatalf("Flatten (notation %d, depth %d): unexpected error %v", notation, depth, err)
+			}
+
+			res, err := Unflatten(flat, "/", opts...)
+			if err != nil {
+				t.Fatalf("Unflatten (notation %d, depth %d): unexpected error %v", notation, depth, err)
+			}
+
+			if !reflect.DeepEqual(res, in) {
+				t.Fatalf(`round trip (notation %d, depth %d): expected
+				%#v, got
+				%#v`, notation, depth, in, res)
+			}
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package maps
+
+// FilterInPlace deletes keys a given function returns false for. Mutates original map.
+func FilterInPlace[T any, K comparable](in map[K]T, filter func(T, K, map[K]T) bool) map[K]T {
+	for k, v := range in {
+		if !filter(v, k, in) {
+			delete(in, k)
+		}
+	}
+
+	return in
+}
+
+// MapInPlace replaces every value of a map with the result of a given function. Mutates original map.
+func MapInPlace[T any, K comparable](in map[K]T, convert func(T, K, map[K]T) T) map[K]T {
+	for k, v := range in {
+		in[k] = convert(v, k, in)
+	}
+
+	return in
+}
+
+// AppendKeys is like Keys, but appends the keys to dst, so it does not allocate when dst has enough capacity.
+// The order of keys is random.
+func AppendKeys[T any, K comparable](dst []K, in map[K]T) []K {
+	for k := range in {
+		dst = append(dst, k)
+	}
+
+	return dst
+}
+
+// AppendValues is like Values, but appends the values to dst, so it does not allocate when dst has enough capacity.
+// The order of values is random.
+func AppendValues[T any, K comparable](dst []T, in map[K]T) []T {
+	for _, v := range in {
+		dst = append(dst, v)
+	}
+
+	return dst
+}
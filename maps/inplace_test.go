@@ -0,0 +1,123 @@
+package maps
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func Test_FilterInPlace(t *testing.T) {
+	in := map[string]int{"a": 1, "b": 2, "c": 3, "d": 4}
+	res := FilterInPlace(in, func(v int, _ string, _ map[string]int) bool { return v%2 == 0 })
+
+	expected := map[string]int{"b": 2, "d": 4}
+	if !reflect.DeepEqual(res, expected) || !reflect.DeepEqual(in, expected) {
+		t.Fatalf(`FilterInPlace: expected
+				%#v, got
+				%#v`, expected, res)
+	}
+
+	if res := FilterInPlace(nil, func(v int, _ string, _ map[string]int) bool { return true }); res != nil {
+		t.Fatalf("FilterInPlace: nil in - nil out, got %#v", res)
+	}
+}
+
+func Test_MapInPlace(t *testing.T) {
+	in := map[string]int{"a": 1, "b": 2}
+	res := MapInPlace(in, func(v int, k string, _ map[string]int) int { return v * 10 })
+
+	expected := map[string]int{"a": 10, "b": 20}
+	if !reflect.DeepEqual(res, expected) || !reflect.DeepEqual(in, expected) {
+		t.Fatalf(`MapInPlace: expected
+				%#v, got
+				%#v`, expected, res)
+	}
+
+	if res := MapInPlace(nil, func(v int, _ string, _ map[string]int) int { return v }); res != nil {
+		t.Fatalf("MapInPlace: nil in - nil out, got %#v", res)
+	}
+}
+
+func Test_AppendKeys(t *testing.T) {
+	res := AppendKeys([]string{"0"}, map[string]int{"b": 2, "a": 1})
+	// sorting as map order is undefined
+	sort.Strings(res)
+
+	expected := []string{"0", "a", "b"}
+	if !reflect.DeepEqual(res, expected) {
+		t.Fatalf(`AppendKeys: expected
+				%#v, got
+				%#v`, expected, res)
+	}
+
+	if res := AppendKeys(nil, map[string]int(nil)); res != nil {
+		t.Fatalf("AppendKeys: nil in - nil out, got %#v", res)
+	}
+}
+
+func Test_AppendValues(t *testing.T) {
+	res := AppendValues([]int{0}, map[string]int{"b": 2, "a": 1})
+	// sorting as map order is undefined
+	sort.Ints(res)
+
+	expected := []int{0, 1, 2}
+	if !reflect.DeepEqual(res, expected) {
+		t.Fatalf(`AppendValues: expected
+				%#v, got
+				%#v`, expected, res)
+	}
+
+	if res := AppendValues(nil, map[string]int(nil)); res != nil {
+		t.Fatalf("AppendValues: nil in - nil out, got %#v", res)
+	}
+}
+
+func Test_InPlace_Allocs(t *testing.T) {
+	in := make(map[int]int, 1000)
+	for i := 0; i < 1000; i++ {
+		in[i] = i
+	}
+	dst := make([]int, 0, len(in))
+
+	tt := []struct {
+		name string
+		run  func()
+	}{
+		{"MapInPlace", func() {
+			MapInPlace(in, func(v int, _ int, _ map[int]int) int { return v + 1 })
+		}},
+		{"FilterInPlace", func() {
+			FilterInPlace(in, func(v int, _ int, _ map[int]int) bool { return true })
+		}},
+		{"AppendKeys", func() {
+			AppendKeys(dst[:0], in)
+		}},
+		{"AppendValues", func() {
+			AppendValues(dst[:0], in)
+		}},
+	}
+
+	for _, tc := range tt {
+		if allocs := testing.AllocsPerRun(100, tc.run); allocs != 0 {
+			t.Fatalf("%s: expected no allocations, got %v", tc.name, allocs)
+		}
+	}
+}
+
+func Benchmark_Keys(b *testing.B) {
+	in := map[int]int{1: 1, 2: 2, 3: 3, 4: 4, 5: 5}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Keys(in)
+	}
+}
+
+func Benchmark_AppendKeys(b *testing.B) {
+	in := map[int]int{1: 1, 2: 2, 3: 3, 4: 4, 5: 5}
+	dst := make([]int, 0, len(in))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dst = AppendKeys(dst[:0], in)
+	}
+}
@@ -0,0 +1,42 @@
+package maps
+
+// Pair holds values of the same key from two maps.
+type Pair[A, B any] struct {
+	Left  A
+	Right B
+}
+
+// JoinResult is a result of Join. Every key of both maps is in exactly one of its fields.
+type JoinResult[A, B any, K comparable] struct {
+	// Matched keys are in both maps.
+	Matched map[K]Pair[A, B]
+	// LeftOnly keys are only in the first map.
+	LeftOnly map[K]A
+	// RightOnly keys are only in the second map.
+	RightOnly map[K]B
+}
+
+// Join matches two maps by their keys. All the fields of the result are non-nil maps.
+func Join[A, B any, K comparable](a map[K]A, b map[K]B) JoinResult[A, B, K] {
+	res := JoinResult[A, B, K]{
+		Matched:   make(map[K]Pair[A, B]),
+		LeftOnly:  make(map[K]A),
+		RightOnly: make(map[K]B),
+	}
+
+	for k, va := range a {
+		if vb, ok := b[k]; ok {
+			res.Matched[k] = Pair[A, B]{Left: va, Right: vb}
+		} else {
+			res.LeftOnly[k] = va
+		}
+	}
+
+	for k, vb := range b {
+		if _, ok := a[k]; !ok {
+			res.RightOnly[k] = vb
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,56 @@
+package maps
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_Join(t *testing.T) {
+	tt := []struct {
+		name     string
+		a        map[int]string
+		b        map[int]float64
+		expected JoinResult[string, float64, int]
+	}{
+		{
+			name: "matched and unmatched keys",
+			a:    map[int]string{1: "alice", 2: "bob", 3: "carol"},
+			b:    map[int]float64{2: 9.5, 3: 7, 4: 1},
+			expected: JoinResult[string, float64, int]{
+				Matched:   map[int]Pair[string, float64]{2: {"bob", 9.5}, 3: {"carol", 7}},
+				LeftOnly:  map[int]string{1: "alice"},
+				RightOnly: map[int]float64{4: 1},
+			},
+		},
+		{
+			name: "nil and map - everything is on the right",
+			a:    nil,
+			b:    map[int]float64{1: 1},
+			expected: JoinResult[string, float64, int]{
+				Matched:   map[int]Pair[string, float64]{},
+				LeftOnly:  map[int]string{},
+				RightOnly: map[int]float64{1: 1},
+			},
+		},
+		{
+			name: "nil and nil - empty result",
+			expected: JoinResult[string, float64, int]{
+				Matched:   map[int]Pair[string, float64]{},
+				LeftOnly:  map[int]string{},
+				RightOnly: map[int]float64{},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			res := Join(tc.a, tc.b)
+
+			if !reflect.DeepEqual(res, tc.expected) {
+				t.Fatalf(`Join %s: expected
+				%#v, got
+				%#v`, tc.name, tc.expected, res)
+			}
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package maps
 
+import "github.com/bullgare/funktional/deepcopy"
+
 // Map creates a new map by iterating over a given map and applying a function to it.
 func Map[T, Y any, K comparable](in map[K]T, convert func(T, K, map[K]T) Y) map[K]Y {
 	if in == nil {
@@ -44,6 +46,19 @@ func Reduce[T, Y any, K comparable](in map[K]T, reduce func(Y, T, K) Y, acc Y) Y
 	return acc
 }
 
+// ReduceUntil is like Reduce, but stops as soon as the function returns true along with the accumulator,
+// which is the result then. Keys after it (in random order) are not visited.
+func ReduceUntil[T, Y any, K comparable](in map[K]T, reduce func(Y, T, K) (Y, bool), acc Y) Y {
+	for k, v := range in {
+		var stop bool
+		if acc, stop = reduce(acc, v, k); stop {
+			break
+		}
+	}
+
+	return acc
+}
+
 // ForEach runs given function for each element of a map.
 func ForEach[T any, K comparable](in map[K]T, fn func(T, K)) {
 	for k, v := range in {
@@ -65,6 +80,13 @@ func Copy[T any, K comparable](in map[K]T) map[K]T {
 	return out
 }
 
+// DeepCopy creates a deep copy of a map, following nested slices, maps, pointers and exported struct fields.
+// Keys are copied as they are. Types implementing deepcopy.Cloner are copied with their Clone method.
+// See deepcopy.Copy for details.
+func DeepCopy[T any, K comparable](in map[K]T) map[K]T {
+	return deepcopy.Copy(in)
+}
+
 // Keys returns all map keys in random order.
 func Keys[T any, K comparable](in map[K]T) []K {
 	if in == nil {
@@ -166,3 +188,20 @@ func InvertGrouped[K1, K2 comparable](in map[K1]K2) map[K2][]K1 {
 	}
 	return out
 }
+
+// EqualBy reports whether two maps have the same keys and all their values are equal by a given function.
+// Nil and empty maps are equal.
+func EqualBy[T any, K comparable](a, b map[K]T, eq func(T, T) bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for k, va := range a {
+		vb, ok := b[k]
+		if !ok || !eq(va, vb) {
+			return false
+		}
+	}
+
+	return true
+}
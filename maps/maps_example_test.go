@@ -133,3 +133,104 @@ func ExampleInvertGrouped() {
 	// Output:
 	// map[int][]string{1:[]string{"a", "e"}, 2:[]string{"b"}, 3:[]string{"c"}, 4:[]string{"d"}}
 }
+
+func ExampleFlatten() {
+	in := map[string]any{
+		"server": map[string]any{"host": "localhost", "ports": []any{80, 443}},
+	}
+
+	out, _ := Flatten(in, ".", WithSliceIndexNotation(SliceIndexBrackets))
+	keys := Keys(out)
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Println(k, out[k])
+	}
+
+	// Output:
+	// server.host localhost
+	// server.ports[0] 80
+	// server.ports[1] 443
+}
+
+func ExampleUnflatten() {
+	in := map[string]any{"server.host": "localhost", "server.ports.0": 80, "server.ports.1": 443}
+
+	out, _ := Unflatten(in, ".", WithSliceIndexNotation(SliceIndexDot))
+	fmt.Printf("%#v\n", out["server"].(map[string]any)["ports"])
+
+	// Output:
+	// []interface {}{80, 443}
+}
+
+func ExampleDiff() {
+	desired := map[string]int{"a": 1, "b": 2, "c": 3}
+	actual := map[string]int{"b": 2, "c": 4, "d": 5}
+
+	diff := Diff(desired, actual, func(x, y int) bool { return x == y })
+	fmt.Printf("%#v\n", diff.Added)
+	fmt.Printf("%#v\n", diff.Removed)
+	fmt.Printf("%+v\n", diff.Changed)
+	fmt.Printf("%#v\n", diff.Unchanged)
+
+	// Output:
+	// map[string]int{"d":5}
+	// map[string]int{"a":1}
+	// map[c:{Old:3 New:4}]
+	// map[string]int{"b":2}
+}
+
+func ExamplePatch() {
+	a := map[string]int{"a": 1, "b": 2}
+	b := map[string]int{"b": 3, "c": 4}
+
+	out := Patch(a, Diff(a, b, func(x, y int) bool { return x == y }))
+	fmt.Printf("%#v\n", out)
+
+	// Output:
+	// map[string]int{"b":3, "c":4}
+}
+
+func ExampleDeepCopy() {
+	in := map[string][]int{"a": {1, 2}}
+
+	out := DeepCopy(in)
+	in["a"][0] = 10
+	fmt.Printf("%#v\n", out)
+
+	// Output:
+	// map[string][]int{"a":[]int{1, 2}}
+}
+
+func ExampleEqualBy() {
+	a := map[string]float64{"a": 1, "b": 2.0001}
+	b := map[string]float64{"a": 1.0001, "b": 2}
+
+	fmt.Println(EqualBy(a, b, func(x, y float64) bool { return x-y < 0.001 && y-x < 0.001 }))
+
+	// Output:
+	// true
+}
+
+func ExampleJoin() {
+	prices := map[string]float64{"apple": 1.2, "pear": 0.8}
+	stock := map[string]int{"apple": 10, "plum": 3}
+
+	res := Join(prices, stock)
+	fmt.Println(res.Matched)
+	fmt.Println(res.LeftOnly, res.RightOnly)
+
+	// Output:
+	// map[apple:{1.2 10}]
+	// map[pear:0.8] map[plum:3]
+}
+
+func ExampleAppendKeys() {
+	buf := make([]string, 0, 16)
+
+	buf = AppendKeys(buf[:0], map[string]int{"b": 2, "a": 1})
+	sort.Strings(buf)
+	fmt.Println(buf)
+
+	// Output:
+	// [a b]
+}
@@ -160,6 +160,32 @@ func Test_Reduce(t *testing.T) {
 	}
 }
 
+func Test_ReduceUntil(t *testing.T) {
+	in := map[string]int{"a": 1, "b": 2, "c": 3, "d": 4}
+
+	calls := 0
+	res := ReduceUntil(in, func(acc string, v int, k string) (string, bool) {
+		calls++
+		return k, v%2 == 0
+	}, "")
+	if calls > 3 || in[res]%2 != 0 {
+		t.Fatalf("ReduceUntil: expected to stop at the first even value, got %q after %d calls", res, calls)
+	}
+
+	calls = 0
+	sum := ReduceUntil(in, func(acc int, v int, _ string) (int, bool) {
+		calls++
+		return acc + v, false
+	}, 0)
+	if sum != 10 || calls != 4 {
+		t.Fatalf("ReduceUntil: expected 10 after 4 calls, got %d after %d calls", sum, calls)
+	}
+
+	if res := ReduceUntil(nil, func(acc int, v int, _ string) (int, bool) { return 1, true }, 5); res != 5 {
+		t.Fatalf("ReduceUntil: expected the accumulator for nil, got %d", res)
+	}
+}
+
 func Test_ForEach(t *testing.T) {
 	in := map[string]int{"a": 1, "b": 2, "c": 3, "d": 4}
 	expectedRes := map[string]bool{"a1": true, "b2": true, "c3": true, "d4": true}
@@ -212,6 +238,24 @@ func Test_Copy(t *testing.T) {
 	}
 }
 
+func Test_DeepCopy(t *testing.T) {
+	in := map[string][]int{"a": {1, 2}, "b": nil}
+
+	res := DeepCopy(in)
+	in["a"][0] = 3
+	expectedRes := map[string][]int{"a": {1, 2}, "b": nil}
+
+	if !reflect.DeepEqual(res, expectedRes) {
+		t.Fatalf(`DeepCopy: expected
+				%#v, got
+				%#v`, expectedRes, res)
+	}
+
+	if res := DeepCopy[int, string](nil); res != nil {
+		t.Fatalf("DeepCopy: nil in - nil out, got %#v", res)
+	}
+}
+
 func Test_Keys(t *testing.T) {
 	tt := []struct {
 		name     string
@@ -476,3 +520,47 @@ func Test_InvertGrouped(t *testing.T) {
 		})
 	}
 }
+
+func Test_EqualBy(t *testing.T) {
+	tt := []struct {
+		name     string
+		a        map[string][]int
+		b        map[string][]int
+		expected bool
+	}{
+		{
+			name:     "equal",
+			a:        map[string][]int{"a": {1}, "b": nil},
+			b:        map[string][]int{"a": {1}, "b": {}},
+			expected: true,
+		},
+		{
+			name:     "different value",
+			a:        map[string][]int{"a": {1}},
+			b:        map[string][]int{"a": {2}},
+			expected: false,
+		},
+		{
+			name:     "different keys",
+			a:        map[string][]int{"a": {1}},
+			b:        map[string][]int{"b": {1}},
+			expected: false,
+		},
+		{
+			name:     "nil and empty are equal",
+			a:        nil,
+			b:        map[string][]int{},
+			expected: true,
+		},
+	}
+
+	eq := func(x, y []int) bool { return reflect.DeepEqual(x, y) || (len(x) == 0 && len(y) == 0) }
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			res := EqualBy(tc.a, tc.b, eq)
+			if res != tc.expected {
+				t.Fatalf("EqualBy %s: expected %v, got %v", tc.name, tc.expected, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package maps
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// RandomKey returns a random key of a map, or false for an empty map. Every key has an equal chance.
+// The iteration order of maps is random, so a source seeded the same way can give different keys,
+// use RandomKeySorted for reproducible results. With a nil source the global source of math/rand is used.
+func RandomKey[T any, K comparable](in map[K]T, r *rand.Rand) (K, bool) {
+	var zero K
+	if len(in) == 0 {
+		return zero, false
+	}
+
+	i := intn(r, len(in))
+	for k := range in {
+		if i == 0 {
+			return k, true
+		}
+		i--
+	}
+
+	return zero, false
+}
+
+// RandomKeySorted is like RandomKey, but sorts keys with a less function before choosing,
+// so a source seeded the same way gives the same key.
+func RandomKeySorted[T any, K comparable](in map[K]T, less func(a, b K) bool, r *rand.Rand) (K, bool) {
+	var zero K
+	if len(in) == 0 {
+		return zero, false
+	}
+
+	keys := Keys(in)
+	sort.Slice(keys, func(i, j int) bool { return less(keys[i], keys[j]) })
+
+	return keys[intn(r, len(keys))], true
+}
+
+func intn(r *rand.Rand, n int) int {
+	if r == nil {
+		return rand.Intn(n)
+	}
+	return r.Intn(n)
+}
@@ -0,0 +1,69 @@
+package maps
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func Test_RandomKey(t *testing.T) {
+	in := map[string]int{"a": 1, "b": 2, "c": 3}
+
+	r := rand.New(rand.NewSource(1))
+	counts := make(map[string]int)
+	for i := 0; i < 3000; i++ {
+		k, ok := RandomKey(in, r)
+		if !ok {
+			t.Fatalf("RandomKey: expected a key")
+		}
+		counts[k]++
+	}
+	for k, c := range counts {
+		if c < 850 || c > 1150 {
+			t.Fatalf("RandomKey: %q is chosen %d times of 3000", k, c)
+		}
+	}
+	if len(counts) != 3 {
+		t.Fatalf("RandomKey: expected all keys to be chosen, got %v", counts)
+	}
+
+	type point struct{ x, y int }
+	if k, ok := RandomKey(map[point]bool{{1, 2}: true}, nil); k != (point{1, 2}) || !ok {
+		t.Fatalf("RandomKey: expected the only struct key, got %v, %v", k, ok)
+	}
+
+	if k, ok := RandomKey(map[string]int(nil), nil); ok {
+		t.Fatalf("RandomKey: expected no key for nil, got %q", k)
+	}
+	if _, ok := RandomKey(in, nil); !ok {
+		t.Fatalf("RandomKey: expected a key with the global source")
+	}
+}
+
+func Test_RandomKeySorted(t *testing.T) {
+	in := map[string]int{"a": 1, "b": 2, "c": 3}
+	less := func(a, b string) bool { return a < b }
+
+	first, ok := RandomKeySorted(in, less, rand.New(rand.NewSource(1)))
+	if !ok {
+		t.Fatalf("RandomKeySorted: expected a key")
+	}
+	for i := 0; i < 20; i++ {
+		if k, _ := RandomKeySorted(in, less, rand.New(rand.NewSource(1))); k != first {
+			t.Fatalf("RandomKeySorted: expected the same key %q for the same seed, got %q", first, k)
+		}
+	}
+
+	r := rand.New(rand.NewSource(1))
+	counts := make(map[string]int)
+	for i := 0; i < 3000; i++ {
+		k, _ := RandomKeySorted(in, less, r)
+		counts[k]++
+	}
+	if len(counts) != 3 {
+		t.Fatalf("RandomKeySorted: expected all keys to be chosen, got %v", counts)
+	}
+
+	if k, ok := RandomKeySorted(map[string]int(nil), less, nil); ok {
+		t.Fatalf("RandomKeySorted: expected no key for nil, got %q", k)
+	}
+}
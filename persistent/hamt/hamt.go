@@ -0,0 +1,346 @@
+// Package hamt implements a persistent (immutable) hash map as a hash array mapped trie.
+// Every modification returns a new version in O(log32 n) sharing most of its structure with the previous one,
+// so maps can be passed between goroutines without copying.
+package hamt
+
+import "math/bits"
+
+const (
+	levelBits = 5
+	levelMask = 1<<levelBits - 1
+	hashBits  = 64
+)
+
+type entry[K comparable, V any] struct {
+	hash  uint64
+	key   K
+	value V
+}
+
+// slot is either an entry or a child node.
+type slot[K comparable, V any] struct {
+	entry *entry[K, V]
+	child *node[K, V]
+}
+
+type node[K comparable, V any] struct {
+	bitmap uint32
+	slots  []slot[K, V]
+	// collisions are only used below the last level, for keys with equal hashes
+	collisions []*entry[K, V]
+}
+
+// PersistentMap is a persistent hash map. The zero value is an empty map ready to use.
+// PersistentMap is safe for concurrent use, as it is never changed in place.
+type PersistentMap[K comparable, V any] struct {
+	root  *node[K, V]
+	count int
+	hash  func(K) uint64
+}
+
+// New creates an empty map with a custom hash function. Equal keys must have equal hashes.
+// The zero value of PersistentMap (or nil hash) uses a default hash function which works for any comparable key,
+// but a custom one is faster for composite keys.
+func New[K comparable, V any](hash func(K) uint64) PersistentMap[K, V] {
+	return PersistentMap[K, V]{hash: hash}
+}
+
+// FromMap creates a persistent map of the elements of a map.
+func FromMap[K comparable, V any](in map[K]V) PersistentMap[K, V] {
+	var m PersistentMap[K, V]
+	for k, v := range in {
+		m = m.Set(k, v)
+	}
+	return m
+}
+
+// Len returns the number of keys.
+func (m PersistentMap[K, V]) Len() int {
+	return m.count
+}
+
+// Get returns a value by its key and whether it was found.
+func (m PersistentMap[K, V]) Get(key K) (V, bool) {
+	h := m.hashOf(key)
+	n := m.root
+	for shift := uint(0); n != nil; shift += levelBits {
+		if n.collisions != nil {
+			for _, e := range n.collisions {
+				if e.key == key {
+					return e.value, true
+				}
+			}
+			break
+		}
+
+		bit := bitFor(h, shift)
+		if n.bitmap&bit == 0 {
+			break
+		}
+		s := n.slots[n.index(bit)]
+		if s.child == nil {
+			if s.entry.hash == h && s.entry.key == key {
+				return s.entry.value, true
+			}
+			break
+		}
+		n = s.child
+	}
+
+	var zero V
+	return zero, false
+}
+
+// Has reports whether the key is in the map.
+func (m PersistentMap[K, V]) Has(key K) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+// Set returns a new map with the key set to the value.
+func (m PersistentMap[K, V]) Set(key K, value V) PersistentMap[K, V] {
+	e := &entry[K, V]{hash: m.hashOf(key), key: key, value: value}
+	root := m.root
+	if root == nil {
+		root = &node[K, V]{}
+	}
+
+	root, added := set(root, e, 0)
+	count := m.count
+	if added {
+		count++
+	}
+	return PersistentMap[K, V]{root: root, count: count, hash: m.hash}
+}
+
+// Delete returns a new map without the key. If there is no such key, the same map is returned.
+func (m PersistentMap[K, V]) Delete(key K) PersistentMap[K, V] {
+	if m.root == nil {
+		return m
+	}
+
+	root, removed := remove(m.root, m.hashOf(key), key, 0)
+	if !removed {
+		return m
+	}
+	return PersistentMap[K, V]{root: root, count: m.count - 1, hash: m.hash}
+}
+
+// Map returns all the elements as a new map. An empty persistent map gives nil.
+func (m PersistentMap[K, V]) Map() map[K]V {
+	if m.count == 0 {
+		return nil
+	}
+
+	out := make(map[K]V, m.count)
+	m.Range(func(k K, v V) bool {
+		out[k] = v
+		return true
+	})
+	return out
+}
+
+// Range calls fn for each key and value until it returns false. The order is defined by the hashes of the keys.
+func (m PersistentMap[K, V]) Range(fn func(K, V) bool) {
+	if m.root != nil {
+		m.root.walk(fn)
+	}
+}
+
+func (m PersistentMap[K, V]) hashOf(key K) uint64 {
+	if m.hash != nil {
+		return m.hash(key)
+	}
+	return defaultHash(key)
+}
+
+func bitFor(hash uint64, shift uint) uint32 {
+	return 1 << ((hash >> shift) & levelMask)
+}
+
+func (n *node[K, V]) index(bit uint32) int {
+	return bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+func (n *node[K, V]) walk(fn func(K, V) bool) bool {
+	for _, e := range n.collisions {
+		if !fn(e.key, e.value) {
+			return false
+		}
+	}
+	for _, s := range n.slots {
+		if s.child != nil {
+			if !s.child.walk(fn) {
+				return false
+			}
+		} else if !fn(s.entry.key, s.entry.value) {
+			return false
+		}
+	}
+	return true
+}
+
+func set[K comparable, V any](n *node[K, V], e *entry[K, V], shift uint) (*node[K, V], bool) {
+	if n.collisions != nil {
+		collisions := make([]*entry[K, V], len(n.collisions), len(n.collisions)+1)
+		copy(collisions, n.collisions)
+		for i, c := range collisions {
+			if c.key == e.key {
+				collisions[i] = e
+				return &node[K, V]{collisions: collisions}, false
+			}
+		}
+		return &node[K, V]{collisions: append(collisions, e)}, true
+	}
+
+	bit := bitFor(e.hash, shift)
+	idx := n.index(bit)
+
+	if n.bitmap&bit == 0 {
+		slots := make([]slot[K, V], len(n.slots)+1)
+		copy(slots, n.slots[:idx])
+		slots[idx] = slot[K, V]{entry: e}
+		copy(slots[idx+1:], n.slots[idx:])
+		return &node[K, V]{bitmap: n.bitmap | bit, slots: slots}, true
+	}
+
+	slots := make([]slot[K, V], len(n.slots))
+	copy(slots, n.slots)
+	added := true
+
+	switch s := n.slots[idx]; {
+	case s.child != nil:
+		slots[idx].child, added = set(s.child, e, shift+levelBits)
+	case s.entry.key == e.key:
+		slots[idx].entry, added = e, false
+	default:
+		slots[idx] = slot[K, V]{child: merge(s.entry, e, shift+levelBits)}
+	}
+
+	return &node[K, V]{bitmap: n.bitmap, slots: slots}, added
+}
+
+// merge creates a node for two entries with different keys which had the same position on the previous level.
+func merge[K comparable, V any](e1, e2 *entry[K, V], shift uint) *node[K, V] {
+	if shift >= hashBits {
+		return &node[K, V]{collisions: []*entry[K, V]{e1, e2}}
+	}
+
+	bit1, bit2 := bitFor(e1.hash, shift), bitFor(e2.hash, shift)
+	switch {
+	case bit1 == bit2:
+		return &node[K, V]{bitmap: bit1, slots: []slot[K, V]{{child: merge(e1, e2, shift+levelBits)}}}
+	case bit1 < bit2:
+		return &node[K, V]{bitmap: bit1 | bit2, slots: []slot[K, V]{{entry: e1}, {entry: e2}}}
+	default:
+		return &node[K, V]{bitmap: bit1 | bit2, slots: []slot[K, V]{{entry: e2}, {entry: e1}}}
+	}
+}
+
+// remove returns a node without the key, or nil if the node became empty.
+func remove[K comparable, V any](n *node[K, V], hash uint64, key K, shift uint) (*node[K, V], bool) {
+	if n.collisions != nil {
+		for i, c := range n.collisions {
+			if c.key == key {
+				if len(n.collisions) == 1 {
+					return nil, true
+				}
+				collisions := make([]*entry[K, V], 0, len(n.collisions)-1)
+				collisions = append(collisions, n.collisions[:i]...)
+				collisions = append(collisions, n.collisions[i+1:]...)
+				return &node[K, V]{collisions: collisions}, true
+			}
+		}
+		return n, false
+	}
+
+	bit := bitFor(hash, shift)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+	idx := n.index(bit)
+	s := n.slots[idx]
+
+	var replacement *slot[K, V]
+	if s.child != nil {
+		child, removed := remove(s.child, hash, key, shift+levelBits)
+		if !removed {
+			return n, false
+		}
+		if child != nil {
+			replacement = &slot[K, V]{child: child}
+			if e := child.single(); e != nil {
+				// a child with the only entry is pulled up
+				replacement = &slot[K, V]{entry: e}
+			}
+		}
+	} else if s.entry.key != key {
+		return n, false
+	}
+
+	if replacement != nil {
+		slots := make([]slot[K, V], len(n.slots))
+		copy(slots, n.slots)
+		slots[idx] = *replacement
+		return &node[K, V]{bitmap: n.bitmap, slots: slots}, true
+	}
+
+	if len(n.slots) == 1 {
+		return nil, true
+	}
+	slots := make([]slot[K, V], 0, len(n.slots)-1)
+	slots = append(slots, n.slots[:idx]...)
+	slots = append(slots, n.slots[idx+1:]...)
+	return &node[K, V]{bitmap: n.bitmap &^ bit, slots: slots}, true
+}
+
+// single returns the entry if it is the only thing in the node.
+func (n *node[K, V]) single() *entry[K, V] {
+	if len(n.collisions) == 1 {
+		return n.collisions[0]
+	}
+	if len(n.slots) == 1 && n.slots[0].child == nil {
+		return n.slots[0].entry
+	}
+	return nil
+}
+
+// Map creates a new persistent map by iterating over a given map and applying a function to it.
+// The result uses the same hash function.
+func Map[T, Y any, K comparable](in PersistentMap[K, T], convert func(T, K, PersistentMap[K, T]) Y) PersistentMap[K, Y] {
+	out := PersistentMap[K, Y]{hash: in.hash}
+	in.Range(func(k K, v T) bool {
+		out = out.Set(k, convert(v, k, in))
+		return true
+	})
+	return out
+}
+
+// Filter iterates over a persistent map and returns a new one with values filtered by a given function.
+func Filter[T any, K comparable](in PersistentMap[K, T], filter func(T, K, PersistentMap[K, T]) bool) PersistentMap[K, T] {
+	out := in
+	in.Range(func(k K, v T) bool {
+		if !filter(v, k, in) {
+			out = out.Delete(k)
+		}
+		return true
+	})
+	return out
+}
+
+// Reduce iterates over a persistent map and reduces it to a given accumulator.
+func Reduce[T, Y any, K comparable](in PersistentMap[K, T], reduce func(Y, T, K) Y, acc Y) Y {
+	in.Range(func(k K, v T) bool {
+		acc = reduce(acc, v, k)
+		return true
+	})
+	return acc
+}
+
+// ForEach runs given function for each element of a persistent map.
+func ForEach[T any, K comparable](in PersistentMap[K, T], fn func(T, K)) {
+	in.Range(func(k K, v T) bool {
+		fn(v, k)
+		return true
+	})
+}
@@ -0,0 +1,23 @@
+package hamt
+
+import "fmt"
+
+func ExamplePersistentMap() {
+	m1 := FromMap(map[string]int{"a": 1, "b": 2})
+	m2 := m1.Set("c", 3).Delete("a")
+
+	fmt.Println(m1.Map(), m2.Map())
+
+	// Output:
+	// map[a:1 b:2] map[b:2 c:3]
+}
+
+func ExampleReduce() {
+	m := FromMap(map[string]int{"a": 1, "b": 2, "c": 3})
+	sum := Reduce(m, func(acc int, v int, _ string) int { return acc + v }, 0)
+
+	fmt.Println(sum)
+
+	// Output:
+	// 6
+}
@@ -0,0 +1,193 @@
+package hamt
+
+import (
+	"math/rand"
+	"reflect"
+	"strconv"
+	"testing"
+	"unsafe"
+)
+
+func checkMap[K comparable, V any](t *testing.T, m PersistentMap[K, V], expected map[K]V) {
+	t.Helper()
+
+	if m.Len() != len(expected) {
+		t.Fatalf("Len: expected %d, got %d", len(expected), m.Len())
+	}
+	for k, v := range expected {
+		got, ok := m.Get(k)
+		if !ok || !reflect.DeepEqual(got, v) {
+			t.Fatalf("Get(%v): expected %v, got %v, %v", k, v, got, ok)
+		}
+	}
+	res := m.Map()
+	if len(expected) == 0 {
+		if res != nil {
+			t.Fatalf("Map: expected nil, got %#v", res)
+		}
+		return
+	}
+	if !reflect.DeepEqual(res, expected) {
+		t.Fatalf("Map: expected %#v, got %#v", expected, res)
+	}
+}
+
+func testRandomOperations(t *testing.T, m PersistentMap[int, int], keys int) {
+	r := rand.New(rand.NewSource(1))
+	expected := map[int]int{}
+
+	var versions []PersistentMap[int, int]
+	var snapshots []map[int]int
+
+	for step := 0; step < 20000; step++ {
+		k := r.Intn(keys)
+		if r.Intn(3) == 0 {
+			m = m.Delete(k)
+			delete(expected, k)
+		} else {
+			v := r.Int()
+			m = m.Set(k, v)
+			expected[k] = v
+		}
+
+		if step%1000 == 0 {
+			versions = append(versions, m)
+			snapshot := make(map[int]int, len(expected))
+			for k, v := range expected {
+				snapshot[k] = v
+			}
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+
+	checkMap(t, m, expected)
+	for i, version := range versions {
+		checkMap(t, version, snapshots[i])
+	}
+
+	for k := range expected {
+		m = m.Delete(k)
+	}
+	checkMap(t, m, map[int]int{})
+}
+
+func Test_PersistentMap(t *testing.T) {
+	testRandomOperations(t, PersistentMap[int, int]{}, 3000)
+}
+
+func Test_PersistentMap_Collisions(t *testing.T) {
+	// only 8 different hashes for all the keys
+	testRandomOperations(t, New[int, int](func(k int) uint64 { return uint64(k % 8) }), 300)
+}
+
+func Test_PersistentMap_DefaultHash(t *testing.T) {
+	type key struct {
+		Name string
+		ID   int
+		F    float64
+		I    any
+	}
+
+	m := PersistentMap[key, string]{}
+	m = m.Set(key{Name: "a", ID: 1, F: 0, I: 1}, "first")
+	m = m.Set(key{Name: "a", ID: 1, F: -0.0, I: 1}, "second")
+	m = m.Set(key{Name: "a", ID: 1, I: "1"}, "third")
+
+	if m.Len() != 2 {
+		t.Fatalf("Len: expected 2, got %d", m.Len())
+	}
+	if v, _ := m.Get(key{Name: "a", ID: 1, I: 1}); v != "second" {
+		t.Fatalf("Get: expected second, got %q", v)
+	}
+	if !m.Has(key{Name: "a", ID: 1, I: "1"}) {
+		t.Fatal("Has: expected the key to be found")
+	}
+}
+
+func Test_PersistentMap_DefaultHash_BlankFields(t *testing.T) {
+	type key struct {
+		ID int
+		_  int32
+	}
+	// the same layout, to set the blank field which cannot be set otherwise
+	type raw struct {
+		ID    int
+		Blank int32
+	}
+
+	a := *(*key)(unsafe.Pointer(&raw{ID: 1, Blank: 1}))
+	b := *(*key)(unsafe.Pointer(&raw{ID: 1, Blank: 2}))
+	if a != b {
+		t.Fatal("==: expected keys differing in a blank field to be equal")
+	}
+	if defaultHash(a) != defaultHash(b) {
+		t.Fatal("defaultHash: expected equal hashes for keys differing in a blank field")
+	}
+
+	m := PersistentMap[key, string]{}.Set(a, "first").Set(b, "second")
+	if m.Len() != 1 {
+		t.Fatalf("Len: expected 1, got %d", m.Len())
+	}
+	if v, _ := m.Get(a); v != "second" {
+		t.Fatalf("Get: expected second, got %q", v)
+	}
+}
+
+func Test_PersistentMap_DeleteMissing(t *testing.T) {
+	m := FromMap(map[string]int{"a": 1})
+	if res := m.Delete("b"); res.Len() != 1 || res.root != m.root {
+		t.Fatal("Delete: expected the same map")
+	}
+
+	var empty PersistentMap[string, int]
+	if res := empty.Delete("a"); res.Len() != 0 {
+		t.Fatal("Delete: expected an empty map")
+	}
+}
+
+func Test_Map(t *testing.T) {
+	in := FromMap(map[string]int{"a": 1, "b": 2})
+	res := Map(in, func(v int, k string, _ PersistentMap[string, int]) string { return k + strconv.Itoa(v) })
+
+	checkMap(t, res, map[string]string{"a": "a1", "b": "b2"})
+}
+
+func Test_Filter(t *testing.T) {
+	in := FromMap(map[string]int{"a": 1, "b": 2, "c": 3, "d": 4})
+	res := Filter(in, func(v int, _ string, _ PersistentMap[string, int]) bool { return v%2 == 0 })
+
+	checkMap(t, res, map[string]int{"b": 2, "d": 4})
+	checkMap(t, in, map[string]int{"a": 1, "b": 2, "c": 3, "d": 4})
+}
+
+func Test_Reduce(t *testing.T) {
+	in := FromMap(map[string]int{"a": 1, "b": 2, "c": 3, "d": 4})
+	res := Reduce(in, func(acc int, v int, _ string) int { return acc + v }, 0)
+
+	if res != 10 {
+		t.Fatalf("Reduce: expected 10, got %d", res)
+	}
+}
+
+func Benchmark_Set(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		var m PersistentMap[int, int]
+		for j := 0; j < 10000; j++ {
+			m = m.Set(j, j)
+		}
+	}
+}
+
+func Benchmark_Get(b *testing.B) {
+	var m PersistentMap[string, int]
+	keys := make([]string, 10000)
+	for j := range keys {
+		keys[j] = strconv.Itoa(j)
+		m = m.Set(keys[j], j)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Get(keys[i%len(keys)])
+	}
+}
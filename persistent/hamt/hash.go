@@ -0,0 +1,104 @@
+package hamt
+
+import (
+	"hash/maphash"
+	"math"
+	"reflect"
+)
+
+var seed = maphash.MakeSeed()
+
+// defaultHash hashes any comparable value consistently with ==.
+// Common key types are hashed directly, everything else is walked via reflection.
+func defaultHash[K comparable](k K) uint64 {
+	switch v := any(k).(type) {
+	case string:
+		return maphash.String(seed, v)
+	case int:
+		return mix(uint64(v))
+	case int64:
+		return mix(uint64(v))
+	case int32:
+		return mix(uint64(v))
+	case uint:
+		return mix(uint64(v))
+	case uint64:
+		return mix(v)
+	case uint32:
+		return mix(uint64(v))
+	}
+
+	var h maphash.Hash
+	h.SetSeed(seed)
+	writeValue(&h, reflect.ValueOf(&k).Elem())
+	return h.Sum64()
+}
+
+// mix is the finalizer of splitmix64, it spreads the bits of sequential integers.
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+func writeUint64(h *maphash.Hash, x uint64) {
+	var buf [8]byte
+	for i := range buf {
+		buf[i] = byte(x >> (8 * i))
+	}
+	_, _ = h.Write(buf[:])
+}
+
+func writeFloat(h *maphash.Hash, f float64) {
+	if f == 0 {
+		// +0 and -0 are equal
+		f = 0
+	}
+	writeUint64(h, math.Float64bits(f))
+}
+
+func writeValue(h *maphash.Hash, v reflect.Value) {
+	switch v.Kind() {
+	case reflect.String:
+		_, _ = h.WriteString(v.String())
+	case reflect.Bool:
+		if v.Bool() {
+			_ = h.WriteByte(1)
+		} else {
+			_ = h.WriteByte(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint64(h, uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeUint64(h, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeFloat(h, v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		writeFloat(h, real(v.Complex()))
+		writeFloat(h, imag(v.Complex()))
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		writeUint64(h, uint64(v.Pointer()))
+	case reflect.Interface:
+		if v.IsNil() {
+			_ = h.WriteByte(0)
+			return
+		}
+		_, _ = h.WriteString(v.Elem().Type().String())
+		writeValue(h, v.Elem())
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			writeValue(h, v.Index(i))
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			// == ignores blank fields, so they must not change the hash
+			if v.Type().Field(i).Name == "_" {
+				continue
+			}
+			writeValue(h, v.Field(i))
+		}
+	}
+}
@@ -0,0 +1,272 @@
+// Package vector implements a persistent (immutable) vector: a radix tree of 32-element arrays with a tail,
+// like Clojure's PersistentVector.
+// Every modification returns a new version in O(log32 n) sharing most of its structure with the previous one,
+// so vectors can be passed between goroutines without copying.
+package vector
+
+import "fmt"
+
+const (
+	bits  = 5
+	width = 1 << bits
+	mask  = width - 1
+)
+
+type node[T any] struct {
+	children []*node[T]
+	values   []T
+}
+
+// Vector is a persistent vector. The zero value is an empty vector ready to use.
+// Vector is safe for concurrent use, as it is never changed in place.
+type Vector[T any] struct {
+	count int
+	shift uint
+	root  *node[T]
+	tail  []T
+}
+
+// New creates a vector of the given elements.
+func New[T any](items ...T) Vector[T] {
+	return FromSlice(items)
+}
+
+// FromSlice creates a vector of the elements of a slice. The slice is copied.
+func FromSlice[T any](in []T) Vector[T] {
+	var v Vector[T]
+	for _, elem := range in {
+		v = v.Append(elem)
+	}
+	return v
+}
+
+// Len returns the number of elements.
+func (v Vector[T]) Len() int {
+	return v.count
+}
+
+// Get returns an element by its index. If the index is out of range, it returns the zero value and false.
+func (v Vector[T]) Get(i int) (T, bool) {
+	if i < 0 || i >= v.count {
+		var zero T
+		return zero, false
+	}
+	return v.leafFor(i)[i&mask], true
+}
+
+// Append returns a new vector with the element added to the end.
+func (v Vector[T]) Append(elem T) Vector[T] {
+	if v.root == nil {
+		v.root, v.shift = &node[T]{}, bits
+	}
+
+	if v.count-v.tailOffset() < width {
+		tail := make([]T, len(v.tail)+1, width)
+		copy(tail, v.tail)
+		tail[len(v.tail)] = elem
+		return Vector[T]{count: v.count + 1, shift: v.shift, root: v.root, tail: tail}
+	}
+
+	tailNode := &node[T]{values: v.tail}
+	root, shift := v.root, v.shift
+	if (v.count >> bits) > (1 << v.shift) {
+		// the tree is full, it grows one level up
+		root = &node[T]{children: []*node[T]{v.root, newPath(v.shift, tailNode)}}
+		shift += bits
+	} else {
+		root = v.pushTail(v.shift, v.root, tailNode)
+	}
+
+	tail := make([]T, 1, width)
+	tail[0] = elem
+	return Vector[T]{count: v.count + 1, shift: shift, root: root, tail: tail}
+}
+
+// Set returns a new vector with the element at the index replaced.
+// Setting the element right after the last one is the same as Append. Any other index out of range panics.
+func (v Vector[T]) Set(i int, elem T) Vector[T] {
+	if i == v.count {
+		return v.Append(elem)
+	}
+	if i < 0 || i > v.count {
+		panic(fmt.Sprintf("vector: index %d out of range [0:%d]", i, v.count))
+	}
+
+	if i >= v.tailOffset() {
+		tail := make([]T, len(v.tail), width)
+		copy(tail, v.tail)
+		tail[i&mask] = elem
+		return Vector[T]{count: v.count, shift: v.shift, root: v.root, tail: tail}
+	}
+
+	return Vector[T]{count: v.count, shift: v.shift, root: set(v.shift, v.root, i, elem), tail: v.tail}
+}
+
+// Pop returns a new vector without the last element. Popping from an empty vector returns an empty vector.
+func (v Vector[T]) Pop() Vector[T] {
+	switch {
+	case v.count <= 1:
+		return Vector[T]{}
+	case v.count-v.tailOffset() > 1:
+		return Vector[T]{count: v.count - 1, shift: v.shift, root: v.root, tail: v.tail[:len(v.tail)-1]}
+	}
+
+	tail := v.leafFor(v.count - 2)
+	root := v.popTail(v.shift, v.root)
+	shift := v.shift
+	if root == nil {
+		root = &node[T]{}
+	}
+	if shift > bits && len(root.children) == 1 {
+		root = root.children[0]
+		shift -= bits
+	}
+
+	return Vector[T]{count: v.count - 1, shift: shift, root: root, tail: tail}
+}
+
+// Last returns the last element. If the vector is empty, it returns the zero value and false.
+func (v Vector[T]) Last() (T, bool) {
+	return v.Get(v.count - 1)
+}
+
+// Slice returns all the elements as a new slice. An empty vector gives nil.
+func (v Vector[T]) Slice() []T {
+	if v.count == 0 {
+		return nil
+	}
+
+	out := make([]T, 0, v.count)
+	for i := 0; i < v.tailOffset(); i += width {
+		out = append(out, v.leafFor(i)...)
+	}
+	return append(out, v.tail...)
+}
+
+// tailOffset is the index of the first element in the tail.
+func (v Vector[T]) tailOffset() int {
+	if v.count < width {
+		return 0
+	}
+	return ((v.count - 1) >> bits) << bits
+}
+
+// leafFor returns the 32-element array containing the element i.
+func (v Vector[T]) leafFor(i int) []T {
+	if i >= v.tailOffset() {
+		return v.tail
+	}
+
+	n := v.root
+	for level := v.shift; level > 0; level -= bits {
+		n = n.children[(i>>level)&mask]
+	}
+	return n.values
+}
+
+func (v Vector[T]) pushTail(level uint, parent, tailNode *node[T]) *node[T] {
+	subidx := ((v.count - 1) >> level) & mask
+	children := make([]*node[T], len(parent.children), len(parent.children)+1)
+	copy(children, parent.children)
+
+	var child *node[T]
+	switch {
+	case level == bits:
+		child = tailNode
+	case subidx < len(parent.children):
+		child = v.pushTail(level-bits, parent.children[subidx], tailNode)
+	default:
+		child = newPath(level-bits, tailNode)
+	}
+
+	if subidx < len(children) {
+		children[subidx] = child
+	} else {
+		children = append(children, child)
+	}
+	return &node[T]{children: children}
+}
+
+func (v Vector[T]) popTail(level uint, n *node[T]) *node[T] {
+	subidx := ((v.count - 2) >> level) & mask
+	if level > bits {
+		child := v.popTail(level-bits, n.children[subidx])
+		if child == nil && subidx == 0 {
+			return nil
+		}
+		children := make([]*node[T], subidx, subidx+1)
+		copy(children, n.children)
+		if child != nil {
+			children = append(children, child)
+		}
+		return &node[T]{children: children}
+	}
+
+	if subidx == 0 {
+		return nil
+	}
+	children := make([]*node[T], subidx)
+	copy(children, n.children)
+	return &node[T]{children: children}
+}
+
+func newPath[T any](level uint, n *node[T]) *node[T] {
+	if level == 0 {
+		return n
+	}
+	return &node[T]{children: []*node[T]{newPath(level-bits, n)}}
+}
+
+func set[T any](level uint, n *node[T], i int, elem T) *node[T] {
+	if level == 0 {
+		values := make([]T, len(n.values))
+		copy(values, n.values)
+		values[i&mask] = elem
+		return &node[T]{values: values}
+	}
+
+	children := make([]*node[T], len(n.children))
+	copy(children, n.children)
+	subidx := (i >> level) & mask
+	children[subidx] = set(level-bits, n.children[subidx], i, elem)
+	return &node[T]{children: children}
+}
+
+// Map creates a vector by iterating over a given vector and applying a function to it.
+func Map[T, Y any](in Vector[T], convert func(T, int, Vector[T]) Y) Vector[Y] {
+	var out Vector[Y]
+	ForEach(in, func(elem T, i int) {
+		out = out.Append(convert(elem, i, in))
+	})
+	return out
+}
+
+// Filter iterates over a vector and returns a new vector with values filtered by a given function.
+func Filter[T any](in Vector[T], filter func(T, int, Vector[T]) bool) Vector[T] {
+	var out Vector[T]
+	ForEach(in, func(elem T, i int) {
+		if filter(elem, i, in) {
+			out = out.Append(elem)
+		}
+	})
+	return out
+}
+
+// Reduce iterates over a vector and reduces it to a given accumulator.
+func Reduce[T, Y any](in Vector[T], reduce func(Y, T, int) Y, acc Y) Y {
+	ForEach(in, func(elem T, i int) {
+		acc = reduce(acc, elem, i)
+	})
+	return acc
+}
+
+// ForEach runs given function for each element of a vector.
+func ForEach[T any](in Vector[T], fn func(T, int)) {
+	i := 0
+	for offset := 0; offset < in.count; offset += width {
+		for _, elem := range in.leafFor(offset) {
+			fn(elem, i)
+			i++
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package vector
+
+import "fmt"
+
+func ExampleVector() {
+	v1 := New(1, 2, 3)
+	v2 := v1.Append(4).Set(0, 10)
+
+	fmt.Println(v1.Slice(), v2.Slice())
+
+	// Output:
+	// [1 2 3] [10 2 3 4]
+}
+
+func ExampleFilter() {
+	v := FromSlice([]int{1, 2, 3, 4})
+	res := Filter(v, func(i int, _ int, _ Vector[int]) bool { return i%2 == 0 })
+
+	fmt.Printf("%#v\n", res.Slice())
+
+	// Output:
+	// []int{2, 4}
+}
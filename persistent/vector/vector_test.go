@@ -0,0 +1,151 @@
+package vector
+
+import (
+	"math/rand"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func Test_Vector_AppendGet(t *testing.T) {
+	for _, n := range []int{0, 1, 31, 32, 33, 64, 1024, 1025, 32*32 + 33, 40000} {
+		var v Vector[int]
+		for i := 0; i < n; i++ {
+			v = v.Append(i)
+		}
+
+		if v.Len() != n {
+			t.Fatalf("Len: expected %d, got %d", n, v.Len())
+		}
+		for i := 0; i < n; i++ {
+			if got, ok := v.Get(i); !ok || got != i {
+				t.Fatalf("Get(%d) of %d: got %d, %v", i, n, got, ok)
+			}
+		}
+		if _, ok := v.Get(n); ok {
+			t.Fatalf("Get(%d) of %d: expected out of range", n, n)
+		}
+		if _, ok := v.Get(-1); ok {
+			t.Fatalf("Get(-1) of %d: expected out of range", n)
+		}
+
+		res := v.Slice()
+		if n == 0 && res != nil {
+			t.Fatalf("Slice: expected nil, got %#v", res)
+		}
+		for i := range res {
+			if res[i] != i {
+				t.Fatalf("Slice of %d: unexpected element %d at %d", n, res[i], i)
+			}
+		}
+	}
+}
+
+func Test_Vector_IsPersistent(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	var v Vector[int]
+	var expected []int
+	versions := []Vector[int]{v}
+	snapshots := [][]int{nil}
+
+	for step := 0; step < 5000; step++ {
+		switch op := r.Intn(10); {
+		case op < 6 || len(expected) == 0:
+			x := r.Int()
+			v = v.Append(x)
+			expected = append(expected, x)
+		case op < 8:
+			i, x := r.Intn(len(expected)), r.Int()
+			v = v.Set(i, x)
+			expected[i] = x
+		default:
+			v = v.Pop()
+			expected = expected[:len(expected)-1]
+		}
+
+		if step%100 == 0 {
+			versions = append(versions, v)
+			snapshots = append(snapshots, append([]int(nil), expected...))
+		}
+	}
+
+	for i, version := range versions {
+		if res := version.Slice(); !reflect.DeepEqual(res, snapshots[i]) && !(len(res) == 0 && len(snapshots[i]) == 0) {
+			t.Fatalf("version %d was changed: expected %v, got %v", i, snapshots[i], res)
+		}
+	}
+}
+
+func Test_Vector_PopToEmpty(t *testing.T) {
+	v := FromSlice(make([]int, 2000))
+	for v.Len() > 0 {
+		v = v.Pop()
+	}
+	v = v.Pop()
+
+	if v.Len() != 0 || v.Slice() != nil {
+		t.Fatalf("Pop: expected empty vector, got %#v", v.Slice())
+	}
+	if _, ok := v.Last(); ok {
+		t.Fatal("Last: expected no elements")
+	}
+	if v = v.Append(1); v.Len() != 1 {
+		t.Fatalf("Append after Pop: expected 1 element, got %d", v.Len())
+	}
+}
+
+func Test_Vector_SetOutOfRange(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Set: expected panic")
+		}
+	}()
+
+	New(1, 2).Set(3, 4)
+}
+
+func Test_Map(t *testing.T) {
+	res := Map(New(1, 2, 3), func(i int, pos int, _ Vector[int]) string { return strconv.Itoa(i * pos) })
+	expected := []string{"0", "2", "6"}
+
+	if !reflect.DeepEqual(res.Slice(), expected) {
+		t.Fatalf("Map: expected %#v, got %#v", expected, res.Slice())
+	}
+}
+
+func Test_Filter(t *testing.T) {
+	in := FromSlice([]int{1, 2, 3, 4, 5, 6, 7, 8, 9})
+	res := Filter(in, func(i int, _ int, _ Vector[int]) bool { return i%2 == 0 })
+	expected := []int{2, 4, 6, 8}
+
+	if !reflect.DeepEqual(res.Slice(), expected) {
+		t.Fatalf("Filter: expected %#v, got %#v", expected, res.Slice())
+	}
+}
+
+func Test_Reduce(t *testing.T) {
+	in := FromSlice(make([]int, 100))
+	res := Reduce(in, func(acc int, _ int, pos int) int { return acc + pos }, 0)
+
+	if res != 4950 {
+		t.Fatalf("Reduce: expected 4950, got %d", res)
+	}
+}
+
+func Benchmark_Append(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		var v Vector[int]
+		for j := 0; j < 10000; j++ {
+			v = v.Append(j)
+		}
+	}
+}
+
+func Benchmark_Set(b *testing.B) {
+	v := FromSlice(make([]int, 10000))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v = v.Set(i%10000, i)
+	}
+}
@@ -0,0 +1,160 @@
+// Package queue implements a double-ended queue and a fixed-capacity ring buffer.
+// Both of them reuse their memory, unlike a slice used as a queue with s = s[1:].
+package queue
+
+const minDequeCapacity = 8
+
+// Deque is a double-ended queue on top of a circular buffer, which grows and shrinks as needed.
+// All the operations are amortized O(1). The zero value is an empty deque ready to use.
+// It is not safe for concurrent use.
+type Deque[T any] struct {
+	buf   []T
+	head  int
+	count int
+}
+
+// NewDeque creates an empty deque with a capacity for at least n elements.
+func NewDeque[T any](n int) *Deque[T] {
+	capacity := minDequeCapacity
+	for capacity < n {
+		capacity *= 2
+	}
+	return &Deque[T]{buf: make([]T, capacity)}
+}
+
+// DequeFromSlice creates a deque of the elements of a slice. The slice is copied.
+func DequeFromSlice[T any](in []T) *Deque[T] {
+	d := NewDeque[T](len(in))
+	d.count = copy(d.buf, in)
+	return d
+}
+
+// Len returns the number of elements.
+func (d *Deque[T]) Len() int {
+	return d.count
+}
+
+// PushBack adds an element to the end.
+func (d *Deque[T]) PushBack(item T) {
+	d.grow()
+	d.buf[d.index(d.count)] = item
+	d.count++
+}
+
+// PushFront adds an element to the beginning.
+func (d *Deque[T]) PushFront(item T) {
+	d.grow()
+	d.head = d.index(len(d.buf) - 1)
+	d.buf[d.head] = item
+	d.count++
+}
+
+// PopBack removes and returns the last element. If the deque is empty, it returns the zero value and false.
+func (d *Deque[T]) PopBack() (T, bool) {
+	var zero T
+	if d.count == 0 {
+		return zero, false
+	}
+
+	i := d.index(d.count - 1)
+	item := d.buf[i]
+	d.buf[i] = zero
+	d.count--
+	d.shrink()
+	return item, true
+}
+
+// PopFront removes and returns the first element. If the deque is empty, it returns the zero value and false.
+func (d *Deque[T]) PopFront() (T, bool) {
+	var zero T
+	if d.count == 0 {
+		return zero, false
+	}
+
+	item := d.buf[d.head]
+	d.buf[d.head] = zero
+	d.head = d.index(1)
+	d.count--
+	d.shrink()
+	return item, true
+}
+
+// Front returns the first element. If the deque is empty, it returns the zero value and false.
+func (d *Deque[T]) Front() (T, bool) {
+	return d.At(0)
+}
+
+// Back returns the last element. If the deque is empty, it returns the zero value and false.
+func (d *Deque[T]) Back() (T, bool) {
+	return d.At(d.count - 1)
+}
+
+// At returns the element at the index i counting from the front.
+// If the index is out of range, it returns the zero value and false.
+func (d *Deque[T]) At(i int) (T, bool) {
+	if i < 0 || i >= d.count {
+		var zero T
+		return zero, false
+	}
+	return d.buf[d.index(i)], true
+}
+
+// Set replaces the element at the index i counting from the front. It returns false if the index is out of range.
+func (d *Deque[T]) Set(i int, item T) bool {
+	if i < 0 || i >= d.count {
+		return false
+	}
+	d.buf[d.index(i)] = item
+	return true
+}
+
+// Clear removes all the elements.
+func (d *Deque[T]) Clear() {
+	*d = Deque[T]{}
+}
+
+// Slice returns a copy of all the elements from the front to the back. An empty deque gives nil.
+func (d *Deque[T]) Slice() []T {
+	if d.count == 0 {
+		return nil
+	}
+	return copyRing(d.buf, d.head, d.count)
+}
+
+// index converts a position from the front into an index of the buffer.
+func (d *Deque[T]) index(i int) int {
+	return (d.head + i) & (len(d.buf) - 1)
+}
+
+func (d *Deque[T]) grow() {
+	if d.buf == nil {
+		d.buf = make([]T, minDequeCapacity)
+		return
+	}
+	if d.count == len(d.buf) {
+		d.resize(len(d.buf) * 2)
+	}
+}
+
+func (d *Deque[T]) shrink() {
+	if len(d.buf) > minDequeCapacity && d.count <= len(d.buf)/4 {
+		d.resize(len(d.buf) / 2)
+	}
+}
+
+// resize moves the elements into a new buffer, its size must be a power of 2.
+func (d *Deque[T]) resize(size int) {
+	buf := make([]T, size)
+	copy(buf, copyRing(d.buf, d.head, d.count))
+	d.buf, d.head = buf, 0
+}
+
+// copyRing copies count elements of a circular buffer starting from head into a new slice.
+func copyRing[T any](buf []T, head, count int) []T {
+	out := make([]T, count)
+	n := copy(out, buf[head:])
+	if n < count {
+		copy(out[n:], buf[:count-n])
+	}
+	return out
+}
@@ -0,0 +1,113 @@
+package queue
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func Test_Deque(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var d Deque[int]
+	var expected []int
+
+	for step := 0; step < 20000; step++ {
+		switch op := r.Intn(4); {
+		case op == 0:
+			d.PushBack(step)
+			expected = append(expected, step)
+		case op == 1:
+			d.PushFront(step)
+			expected = append([]int{step}, expected...)
+		case op == 2:
+			v, ok := d.PopBack()
+			if ok != (len(expected) > 0) || (ok && v != expected[len(expected)-1]) {
+				t.Fatalf("PopBack: unexpected %d, %v", v, ok)
+			}
+			if ok {
+				expected = expected[:len(expected)-1]
+			}
+		default:
+			v, ok := d.PopFront()
+			if ok != (len(expected) > 0) || (ok && v != expected[0]) {
+				t.Fatalf("PopFront: unexpected %d, %v", v, ok)
+			}
+			if ok {
+				expected = expected[1:]
+			}
+		}
+
+		if d.Len() != len(expected) {
+			t.Fatalf("Len: expected %d, got %d", len(expected), d.Len())
+		}
+		if len(expected) > 0 {
+			i := r.Intn(len(expected))
+			if v, ok := d.At(i); !ok || v != expected[i] {
+				t.Fatalf("At(%d): expected %d, got %d", i, expected[i], v)
+			}
+		}
+	}
+
+	if res := d.Slice(); !reflect.DeepEqual(res, expected) && len(expected) > 0 {
+		t.Fatalf("Slice: expected %v, got %v", expected, res)
+	}
+}
+
+func Test_Deque_GrowsAndShrinks(t *testing.T) {
+	d := NewDeque[int](0)
+	for i := 0; i < 1000; i++ {
+		d.PushBack(i)
+	}
+	if len(d.buf) != 1024 {
+		t.Fatalf("grow: expected buffer of 1024, got %d", len(d.buf))
+	}
+
+	for i := 0; i < 1000; i++ {
+		d.PopFront()
+	}
+	if len(d.buf) != minDequeCapacity {
+		t.Fatalf("shrink: expected buffer of %d, got %d", minDequeCapacity, len(d.buf))
+	}
+}
+
+func Test_Deque_Empty(t *testing.T) {
+	var d Deque[string]
+
+	if _, ok := d.PopFront(); ok {
+		t.Fatal("PopFront: expected no elements")
+	}
+	if _, ok := d.PopBack(); ok {
+		t.Fatal("PopBack: expected no elements")
+	}
+	if _, ok := d.Front(); ok {
+		t.Fatal("Front: expected no elements")
+	}
+	if _, ok := d.Back(); ok {
+		t.Fatal("Back: expected no elements")
+	}
+	if d.Set(0, "a") {
+		t.Fatal("Set: expected out of range")
+	}
+	if d.Slice() != nil {
+		t.Fatal("Slice: expected nil")
+	}
+}
+
+func Test_DequeFromSlice(t *testing.T) {
+	in := []int{1, 2, 3}
+	d := DequeFromSlice(in)
+	d.PushFront(0)
+	d.Set(1, 10)
+
+	if res := d.Slice(); !reflect.DeepEqual(res, []int{0, 10, 2, 3}) {
+		t.Fatalf("DequeFromSlice: unexpected %v", res)
+	}
+	if !reflect.DeepEqual(in, []int{1, 2, 3}) {
+		t.Fatalf("DequeFromSlice: original slice was changed: %v", in)
+	}
+
+	d.Clear()
+	if d.Len() != 0 {
+		t.Fatalf("Clear: expected empty deque, got %v", d.Slice())
+	}
+}
@@ -0,0 +1,35 @@
+package queue
+
+import (
+	"fmt"
+
+	"github.com/bullgare/funktional/slices"
+)
+
+func ExampleDeque() {
+	var d Deque[int]
+	d.PushBack(2)
+	d.PushBack(3)
+	d.PushFront(1)
+
+	front, _ := d.PopFront()
+	back, _ := d.PopBack()
+	fmt.Println(front, back, d.Slice())
+
+	// Output:
+	// 1 3 [2]
+}
+
+func ExampleRingBuffer() {
+	// keep the last 3 measurements
+	r := NewRingBuffer[float64](3, OverwriteOldest)
+	for _, v := range []float64{1, 2, 3, 4, 5} {
+		r.Push(v)
+	}
+
+	sum := slices.Reduce(r.Slice(), func(acc float64, v float64, _ int) float64 { return acc + v }, 0)
+	fmt.Println(r.Slice(), sum/float64(r.Len()))
+
+	// Output:
+	// [3 4 5] 4
+}
@@ -0,0 +1,106 @@
+package queue
+
+// OverflowMode defines what RingBuffer does when a new element is pushed into a full buffer.
+type OverflowMode int
+
+const (
+	// OverwriteOldest drops the oldest element to make room for the new one.
+	OverwriteOldest OverflowMode = iota
+	// RejectWhenFull keeps the buffer as it is and rejects the new element.
+	RejectWhenFull
+)
+
+// RingBuffer is a FIFO queue of a fixed capacity. All the operations are O(1) and never allocate.
+// It is not safe for concurrent use.
+type RingBuffer[T any] struct {
+	buf   []T
+	head  int
+	count int
+	mode  OverflowMode
+}
+
+// NewRingBuffer creates an empty ring buffer. Capacity less than 1 is treated as 1.
+func NewRingBuffer[T any](capacity int, mode OverflowMode) *RingBuffer[T] {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &RingBuffer[T]{buf: make([]T, capacity), mode: mode}
+}
+
+// Len returns the number of elements.
+func (r *RingBuffer[T]) Len() int {
+	return r.count
+}
+
+// Cap returns the capacity.
+func (r *RingBuffer[T]) Cap() int {
+	return len(r.buf)
+}
+
+// IsFull reports whether the buffer has no free space.
+func (r *RingBuffer[T]) IsFull() bool {
+	return r.count == len(r.buf)
+}
+
+// Push adds an element to the end. If the buffer is full, it either overwrites the oldest element,
+// or returns false in RejectWhenFull mode.
+func (r *RingBuffer[T]) Push(item T) bool {
+	if r.IsFull() {
+		if r.mode == RejectWhenFull {
+			return false
+		}
+		r.buf[r.head] = item
+		r.head = (r.head + 1) % len(r.buf)
+		return true
+	}
+
+	r.buf[(r.head+r.count)%len(r.buf)] = item
+	r.count++
+	return true
+}
+
+// Pop removes and returns the oldest element. If the buffer is empty, it returns the zero value and false.
+func (r *RingBuffer[T]) Pop() (T, bool) {
+	var zero T
+	if r.count == 0 {
+		return zero, false
+	}
+
+	item := r.buf[r.head]
+	r.buf[r.head] = zero
+	r.head = (r.head + 1) % len(r.buf)
+	r.count--
+	return item, true
+}
+
+// Peek returns the oldest element without removing it. If the buffer is empty, it returns the zero value and false.
+func (r *RingBuffer[T]) Peek() (T, bool) {
+	return r.At(0)
+}
+
+// At returns the element at the index i counting from the oldest one.
+// If the index is out of range, it returns the zero value and false.
+func (r *RingBuffer[T]) At(i int) (T, bool) {
+	if i < 0 || i >= r.count {
+		var zero T
+		return zero, false
+	}
+	return r.buf[(r.head+i)%len(r.buf)], true
+}
+
+// Clear removes all the elements.
+func (r *RingBuffer[T]) Clear() {
+	var zero T
+	for i := range r.buf {
+		r.buf[i] = zero
+	}
+	r.head, r.count = 0, 0
+}
+
+// Slice returns a copy of all the elements from the oldest to the newest. An empty buffer gives nil.
+func (r *RingBuffer[T]) Slice() []T {
+	if r.count == 0 {
+		return nil
+	}
+	return copyRing(r.buf, r.head, r.count)
+}
@@ -0,0 +1,64 @@
+package queue
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_RingBuffer_OverwriteOldest(t *testing.T) {
+	r := NewRingBuffer[int](3, OverwriteOldest)
+	for i := 1; i <= 5; i++ {
+		if !r.Push(i) {
+			t.Fatalf("Push(%d): expected to be accepted", i)
+		}
+	}
+
+	if !r.IsFull() || r.Len() != 3 || r.Cap() != 3 {
+		t.Fatalf("expected full buffer of 3, got %d/%d", r.Len(), r.Cap())
+	}
+	if res := r.Slice(); !reflect.DeepEqual(res, []int{3, 4, 5}) {
+		t.Fatalf("Slice: expected [3 4 5], got %v", res)
+	}
+	if v, _ := r.At(2); v != 5 {
+		t.Fatalf("At(2): expected 5, got %d", v)
+	}
+	if v, _ := r.Pop(); v != 3 {
+		t.Fatalf("Pop: expected 3, got %d", v)
+	}
+	r.Push(6)
+	if res := r.Slice(); !reflect.DeepEqual(res, []int{4, 5, 6}) {
+		t.Fatalf("Slice: expected [4 5 6], got %v", res)
+	}
+}
+
+func Test_RingBuffer_RejectWhenFull(t *testing.T) {
+	r := NewRingBuffer[string](2, RejectWhenFull)
+
+	if !r.Push("a") || !r.Push("b") {
+		t.Fatal("Push: expected to be accepted")
+	}
+	if r.Push("c") {
+		t.Fatal("Push: expected to be rejected")
+	}
+	if v, _ := r.Peek(); v != "a" {
+		t.Fatalf("Peek: expected a, got %q", v)
+	}
+	if res := r.Slice(); !reflect.DeepEqual(res, []string{"a", "b"}) {
+		t.Fatalf("Slice: expected [a b], got %v", res)
+	}
+
+	r.Clear()
+	if _, ok := r.Pop(); ok || r.Slice() != nil {
+		t.Fatal("Clear: expected empty buffer")
+	}
+}
+
+func Test_RingBuffer_MinCapacity(t *testing.T) {
+	r := NewRingBuffer[int](0, OverwriteOldest)
+	r.Push(1)
+	r.Push(2)
+
+	if res := r.Slice(); !reflect.DeepEqual(res, []int{2}) {
+		t.Fatalf("Slice: expected [2], got %v", res)
+	}
+}
@@ -0,0 +1,346 @@
+package quickcheck
+
+import (
+	"math"
+	"math/rand"
+)
+
+// tree is a generated value with lazily computed smaller versions of it, simplest first.
+type tree[T any] struct {
+	value   T
+	shrinks func() []tree[T]
+}
+
+func leaf[T any](v T) tree[T] {
+	return tree[T]{value: v, shrinks: func() []tree[T] { return nil }}
+}
+
+func mapTree[T, Y any](t tree[T], convert func(T) Y) tree[Y] {
+	return tree[Y]{
+		value: convert(t.value),
+		shrinks: func() []tree[Y] {
+			children := t.shrinks()
+			res := make([]tree[Y], 0, len(children))
+			for _, c := range children {
+				res = append(res, mapTree(c, convert))
+			}
+			return res
+		},
+	}
+}
+
+// Gen generates random values of type T which can be shrunk when a property fails.
+// The size hint grows from 0 during a run, generators use it to limit numbers and lengths.
+type Gen[T any] struct {
+	generate func(r *rand.Rand, size int) tree[T]
+}
+
+// Generate returns a random value.
+func (g Gen[T]) Generate(r *rand.Rand, size int) T {
+	return g.generate(r, size).value
+}
+
+// Const always generates the same value.
+func Const[T any](v T) Gen[T] {
+	return Gen[T]{generate: func(*rand.Rand, int) tree[T] { return leaf(v) }}
+}
+
+// Int generates ints in [min, max], shrinking towards the closest to zero.
+func Int(min, max int) Gen[int] {
+	if min > max {
+		min, max = max, min
+	}
+	target := 0
+	switch {
+	case target < min:
+		target = min
+	case target > max:
+		target = max
+	}
+
+	// the span is computed in uint64, as max-min overflows int for wide ranges
+	span := uint64(max) - uint64(min)
+	return Gen[int]{generate: func(r *rand.Rand, _ int) tree[int] {
+		return intTree(int(uint64(min)+uniformUint64(r, span)), target)
+	}}
+}
+
+// uniformUint64 returns a random number in [0, max].
+func uniformUint64(r *rand.Rand, max uint64) uint64 {
+	if max < math.MaxInt64 {
+		return uint64(r.Int63n(int64(max) + 1))
+	}
+	// at least half of the values fit, so it takes two attempts on average
+	for {
+		if v := r.Uint64(); v <= max {
+			return v
+		}
+	}
+}
+
+// Ints generates ints in [-size, size], shrinking towards zero.
+func Ints() Gen[int] {
+	return Gen[int]{generate: func(r *rand.Rand, size int) tree[int] {
+		return intTree(r.Intn(2*size+1)-size, 0)
+	}}
+}
+
+// intTree shrinks v towards target: first the target itself, then halving the distance.
+// The distance is computed in uint64, as v-target overflows int when they are far apart.
+func intTree(v, target int) tree[int] {
+	return tree[int]{
+		value: v,
+		shrinks: func() []tree[int] {
+			down := v > target
+			dist := uint64(target) - uint64(v)
+			if down {
+				dist = uint64(v) - uint64(target)
+			}
+
+			var res []tree[int]
+			for d := dist; d != 0; d /= 2 {
+				// wrapping arithmetic gives the right value, as it is between v and target
+				if down {
+					res = append(res, intTree(int(uint64(v)-d), target))
+				} else {
+					res = append(res, intTree(int(uint64(v)+d), target))
+				}
+			}
+			return res
+		},
+	}
+}
+
+// Float64 generates floats in [min, max), shrinking towards the closest to zero.
+func Float64(min, max float64) Gen[float64] {
+	if min > max {
+		min, max = max, min
+	}
+	target := 0.0
+	switch {
+	case target < min:
+		target = min
+	case target >= max && min < max:
+		// max is excluded, so the closest to zero is the float right below it
+		target = math.Nextafter(max, min)
+	case target >= max:
+		target = min
+	}
+
+	return Gen[float64]{generate: func(r *rand.Rand, _ int) tree[float64] {
+		return floatTree(min+r.Float64()*(max-min), target, min, max, 0)
+	}}
+}
+
+// floatTree shrinks v towards target, keeping shrinks in [min, max).
+func floatTree(v, target, min, max float64, depth int) tree[float64] {
+	return tree[float64]{
+		value: v,
+		shrinks: func() []tree[float64] {
+			// floats can be halved forever, so the depth is limited
+			if depth > 20 || v == target {
+				return nil
+			}
+			inRange := func(x float64) bool { return x >= min && (x < max || x == min) }
+
+			res := []tree[float64]{floatTree(target, target, min, max, depth+1)}
+			if whole := math.Trunc(v); whole != v && whole != target && inRange(whole) {
+				res = append(res, floatTree(whole, target, min, max, depth+1))
+			}
+			if half := target + (v-target)/2; inRange(half) {
+				res = append(res, floatTree(half, target, min, max, depth+1))
+			}
+			return res
+		},
+	}
+}
+
+// Bool generates bools, shrinking towards false.
+func Bool() Gen[bool] {
+	return Map(Int(0, 1), func(i int) bool { return i == 1 })
+}
+
+// OneOf picks one of given values, shrinking towards the first ones. It panics without values.
+func OneOf[T any](values ...T) Gen[T] {
+	if len(values) == 0 {
+		panic("quickcheck: OneOf needs at least one value")
+	}
+	return Map(Int(0, len(values)-1), func(i int) T { return values[i] })
+}
+
+// Alphabets for StringOf.
+const (
+	Lowercase = "abcdefghijklmnopqrstuvwxyz"
+	Letters   = Lowercase + "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	Digits    = "0123456789"
+)
+
+// String generates strings of lowercase letters, up to size long.
+func String() Gen[string] {
+	return StringOf(Lowercase)
+}
+
+// StringOf generates strings of runes from an alphabet, up to size long.
+// Strings shrink to shorter ones and to the runes at the beginning of the alphabet.
+func StringOf(alphabet string) Gen[string] {
+	runes := OneOf([]rune(alphabet)...)
+	return Map(SliceOf(runes), func(rs []rune) string { return string(rs) })
+}
+
+// SliceOf generates slices of values from a generator, up to size long.
+// Slices shrink by removing elements and by shrinking elements.
+func SliceOf[T any](g Gen[T]) Gen[[]T] {
+	return Gen[[]T]{generate: func(r *rand.Rand, size int) tree[[]T] {
+		elems := make([]tree[T], r.Intn(size+1))
+		for i := range elems {
+			elems[i] = g.generate(r, size)
+		}
+		return sliceTree(elems)
+	}}
+}
+
+// SliceOfN generates slices of exactly n values from a generator. Slices shrink by shrinking elements.
+func SliceOfN[T any](n int, g Gen[T]) Gen[[]T] {
+	return Gen[[]T]{generate: func(r *rand.Rand, size int) tree[[]T] {
+		elems := make([]tree[T], n)
+		for i := range elems {
+			elems[i] = g.generate(r, size)
+		}
+		return fixedSliceTree(elems)
+	}}
+}
+
+func values[T any](elems []tree[T]) []T {
+	res := make([]T, len(elems))
+	for i, e := range elems {
+		res[i] = e.value
+	}
+	return res
+}
+
+func sliceTree[T any](elems []tree[T]) tree[[]T] {
+	return tree[[]T]{
+		value: values(elems),
+		shrinks: func() []tree[[]T] {
+			var res []tree[[]T]
+			// remove chunks, from the whole slice down to single elements
+			for chunk := len(elems); chunk > 0; chunk /= 2 {
+				for start := 0; start+chunk <= len(elems); start += chunk {
+					rest := make([]tree[T], 0, len(elems)-chunk)
+					rest = append(rest, elems[:start]...)
+					rest = append(rest, elems[start+chunk:]...)
+					res = append(res, sliceTree(rest))
+				}
+			}
+			return append(res, shrinkElements(elems, sliceTree[T])...)
+		},
+	}
+}
+
+func fixedSliceTree[T any](elems []tree[T]) tree[[]T] {
+	return tree[[]T]{
+		value: values(elems),
+		shrinks: func() []tree[[]T] {
+			return shrinkElements(elems, fixedSliceTree[T])
+		},
+	}
+}
+
+// shrinkElements replaces one element at a time with its shrinks.
+func shrinkElements[T any](elems []tree[T], build func([]tree[T]) tree[[]T]) []tree[[]T] {
+	var res []tree[[]T]
+	for i, e := range elems {
+		for _, s := range e.shrinks() {
+			next := append([]tree[T](nil), elems...)
+			next[i] = s
+			res = append(res, build(next))
+		}
+	}
+	return res
+}
+
+// MapOf generates maps with keys and values from generators, up to size long.
+// Maps shrink by removing keys and by shrinking keys and values.
+func MapOf[K comparable, V any](keys Gen[K], vals Gen[V]) Gen[map[K]V] {
+	type entry struct {
+		k K
+		v V
+	}
+	entries := SliceOf(Map2(keys, vals, func(k K, v V) entry { return entry{k, v} }))
+
+	return Map(entries, func(es []entry) map[K]V {
+		res := make(map[K]V, len(es))
+		for _, e := range es {
+			res[e.k] = e.v
+		}
+		return res
+	})
+}
+
+// Map converts generated values with a function. Values shrink the way the original ones do.
+func Map[T, Y any](g Gen[T], convert func(T) Y) Gen[Y] {
+	return Gen[Y]{generate: func(r *rand.Rand, size int) tree[Y] {
+		return mapTree(g.generate(r, size), convert)
+	}}
+}
+
+// Map2 combines values of two generators, for example into a struct.
+// Values shrink by shrinking the first value, then the second one.
+func Map2[A, B, Y any](a Gen[A], b Gen[B], combine func(A, B) Y) Gen[Y] {
+	return Gen[Y]{generate: func(r *rand.Rand, size int) tree[Y] {
+		return pairTree(a.generate(r, size), b.generate(r, size), combine)
+	}}
+}
+
+func pairTree[A, B, Y any](a tree[A], b tree[B], combine func(A, B) Y) tree[Y] {
+	return tree[Y]{
+		value: combine(a.value, b.value),
+		shrinks: func() []tree[Y] {
+			var res []tree[Y]
+			for _, sa := range a.shrinks() {
+				res = append(res, pairTree(sa, b, combine))
+			}
+			for _, sb := range b.shrinks() {
+				res = append(res, pairTree(a, sb, combine))
+			}
+			return res
+		},
+	}
+}
+
+// Map3 combines values of three generators, for example into a struct.
+func Map3[A, B, C, Y any](a Gen[A], b Gen[B], c Gen[C], combine func(A, B, C) Y) Gen[Y] {
+	type ab struct {
+		a A
+		b B
+	}
+	return Map2(Map2(a, b, func(a A, b B) ab { return ab{a, b} }), c, func(x ab, c C) Y { return combine(x.a, x.b, c) })
+}
+
+// Filter keeps generated values a function returns true for, shrinks are filtered too.
+// It panics if it cannot generate a value after 100 attempts, so the function should accept most of the values.
+func Filter[T any](g Gen[T], keep func(T) bool) Gen[T] {
+	return Gen[T]{generate: func(r *rand.Rand, size int) tree[T] {
+		for i := 0; i < 100; i++ {
+			if t := g.generate(r, size); keep(t.value) {
+				return filterTree(t, keep)
+			}
+		}
+		panic("quickcheck: Filter rejected 100 values in a row")
+	}}
+}
+
+func filterTree[T any](t tree[T], keep func(T) bool) tree[T] {
+	return tree[T]{
+		value: t.value,
+		shrinks: func() []tree[T] {
+			var res []tree[T]
+			for _, s := range t.shrinks() {
+				if keep(s.value) {
+					res = append(res, filterTree(s, keep))
+				}
+			}
+			return res
+		},
+	}
+}
@@ -0,0 +1,153 @@
+// Package quickcheck implements property-based testing: a property is checked against many random values,
+// and when it fails, the failing value is shrunk to a minimal one, which is much easier to debug.
+//
+// Generators compose, so values of any shape (including structs) can be built with Map, Map2 and Map3
+// out of the basic ones, keeping the shrinking.
+package quickcheck
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+type config struct {
+	seed       int64
+	hasSeed    bool
+	runs       int
+	maxSize    int
+	maxShrinks int
+}
+
+// Option configures Check and ForAll.
+type Option func(*config)
+
+// WithSeed makes the random values reproducible. By default the seed depends on the current time
+// and is reported on failures.
+func WithSeed(seed int64) Option {
+	return func(c *config) {
+		c.seed = seed
+		c.hasSeed = true
+	}
+}
+
+// WithRuns sets the number of random values to check, 100 by default.
+func WithRuns(n int) Option {
+	return func(c *config) {
+		c.runs = n
+	}
+}
+
+// WithMaxSize sets the limit of the size hint for generators, 100 by default. Negative limits are treated as 0.
+func WithMaxSize(n int) Option {
+	return func(c *config) {
+		if n < 0 {
+			n = 0
+		}
+		c.maxSize = n
+	}
+}
+
+// WithMaxShrinks limits the number of successful shrinking steps, 1000 by default.
+func WithMaxShrinks(n int) Option {
+	return func(c *config) {
+		c.maxShrinks = n
+	}
+}
+
+// Failure describes a value a property failed for.
+type Failure[T any] struct {
+	// Seed reproduces the failure with WithSeed.
+	Seed int64
+	// Run is the number of the run which failed, starting from 1.
+	Run int
+	// Original is the value which failed first.
+	Original T
+	// Shrunk is the smallest value the property still fails for.
+	Shrunk T
+	// Shrinks is the number of shrinking steps from Original to Shrunk.
+	Shrinks int
+	// Panic is a value the property panicked with for Shrunk, if it did.
+	Panic any
+}
+
+func (f *Failure[T]) String() string {
+	msg := fmt.Sprintf("property failed after %d runs (seed %d), shrunk in %d steps\n\tfrom: %#v\n\t  to: %#v",
+		f.Run, f.Seed, f.Shrinks, f.Original, f.Shrunk)
+	if f.Panic != nil {
+		msg += fmt.Sprintf("\n\tpanic: %v", f.Panic)
+	}
+	return msg
+}
+
+// Check checks a property against random values, it returns nil if the property held for all of them.
+// A property panicking is a failure as well.
+func Check[T any](g Gen[T], property func(T) bool, opts ...Option) *Failure[T] {
+	cfg := config{runs: 100, maxSize: 100, maxShrinks: 1000}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if !cfg.hasSeed {
+		cfg.seed = time.Now().UnixNano()
+	}
+
+	r := rand.New(rand.NewSource(cfg.seed))
+	for run := 0; run < cfg.runs; run++ {
+		size := cfg.maxSize
+		if cfg.runs > 1 {
+			size = run * cfg.maxSize / (cfg.runs - 1)
+		}
+
+		t := g.generate(r, size)
+		if ok, _ := holds(property, t.value); ok {
+			continue
+		}
+
+		f := &Failure[T]{Seed: cfg.seed, Run: run + 1, Original: t.value}
+		f.Shrunk, f.Shrinks, f.Panic = shrink(t, property, cfg.maxShrinks)
+		return f
+	}
+
+	return nil
+}
+
+// ForAll checks a property against random values and fails the test with the shrunk value if it does not hold.
+func ForAll[T any](t testing.TB, g Gen[T], property func(T) bool, opts ...Option) {
+	t.Helper()
+
+	if f := Check(g, property, opts...); f != nil {
+		t.Fatal(f.String())
+	}
+}
+
+// shrink goes to the first smaller value the property still fails for, as long as there is one.
+func shrink[T any](t tree[T], property func(T) bool, maxShrinks int) (T, int, any) {
+	_, panicked := holds(property, t.value)
+
+	steps := 0
+	for steps < maxShrinks {
+		found := false
+		for _, s := range t.shrinks() {
+			if ok, p := holds(property, s.value); !ok {
+				t, panicked, found = s, p, true
+				break
+			}
+		}
+		if !found {
+			break
+		}
+		steps++
+	}
+
+	return t.value, steps, panicked
+}
+
+func holds[T any](property func(T) bool, v T) (ok bool, panicked any) {
+	defer func() {
+		if p := recover(); p != nil {
+			ok, panicked = false, p
+		}
+	}()
+	return property(v), nil
+}
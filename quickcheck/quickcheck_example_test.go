@@ -0,0 +1,51 @@
+package quickcheck
+
+import (
+	"fmt"
+
+	"github.com/bullgare/funktional/slices"
+)
+
+func ExampleCheck() {
+	// a buggy callback: it was only tested with short slices
+	dedupe := func(in []int) []int {
+		return slices.Filter(in, func(v int, pos int, all []int) bool {
+			return pos == 0 || all[pos-1] != v
+		})
+	}
+
+	f := Check(SliceOf(Int(0, 3)), func(in []int) bool {
+		out := dedupe(in)
+		seen := map[int]bool{}
+		for _, v := range out {
+			if seen[v] {
+				return false
+			}
+			seen[v] = true
+		}
+		return true
+	}, WithSeed(1))
+
+	// it only removes neighbouring duplicates
+	fmt.Println(f.Shrunk)
+
+	// Output:
+	// [3 0 3]
+}
+
+func ExampleMap2() {
+	type user struct {
+		Name string
+		Age  int
+	}
+
+	users := Map2(StringOf(Letters), Int(0, 120), func(name string, age int) user {
+		return user{Name: name, Age: age}
+	})
+
+	f := Check(users, func(u user) bool { return u.Age < 18 || len(u.Name) > 0 }, WithSeed(1))
+	fmt.Printf("%+v\n", f.Shrunk)
+
+	// Output:
+	// {Name: Age:18}
+}
@@ -0,0 +1,256 @@
+package quickcheck
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bullgare/funktional/maps"
+	"github.com/bullgare/funktional/slices"
+)
+
+func Test_Generators_Ranges(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 1000; i++ {
+		if v := Int(-3, 5).Generate(r, 10); v < -3 || v > 5 {
+			t.Fatalf("Int: %d is out of range", v)
+		}
+		if v := Ints().Generate(r, 7); v < -7 || v > 7 {
+			t.Fatalf("Ints: %d is out of size", v)
+		}
+		if v := Float64(1, 2).Generate(r, 10); v < 1 || v >= 2 {
+			t.Fatalf("Float64: %f is out of range", v)
+		}
+		if v := StringOf(Digits).Generate(r, 5); len(v) > 5 || strings.Trim(v, Digits) != "" {
+			t.Fatalf("StringOf: unexpected %q", v)
+		}
+		if v := SliceOfN(3, Bool()).Generate(r, 10); len(v) != 3 {
+			t.Fatalf("SliceOfN: unexpected %v", v)
+		}
+		if v := MapOf(Int(0, 2), String()).Generate(r, 10); len(v) > 3 {
+			t.Fatalf("MapOf: unexpected %v", v)
+		}
+		if v := Filter(Ints(), func(i int) bool { return i%2 == 0 }).Generate(r, 100); v%2 != 0 {
+			t.Fatalf("Filter: unexpected %d", v)
+		}
+	}
+}
+
+func Test_Int_Boundaries(t *testing.T) {
+	tt := []struct {
+		name     string
+		min, max int
+		target   int
+	}{
+		{name: "up to max", min: 0, max: math.MaxInt, target: 0},
+		{name: "from min", min: math.MinInt, max: 0, target: 0},
+		{name: "whole int", min: math.MinInt, max: math.MaxInt, target: 0},
+		{name: "near max", min: math.MaxInt - 1, max: math.MaxInt, target: math.MaxInt - 1},
+		{name: "near min", min: math.MinInt, max: math.MinInt + 1, target: math.MinInt + 1},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			r := rand.New(rand.NewSource(1))
+			g := Int(tc.min, tc.max)
+			for i := 0; i < 100; i++ {
+				if v := g.Generate(r, 10); v < tc.min || v > tc.max {
+					t.Fatalf("Int %s: %d is out of range", tc.name, v)
+				}
+			}
+
+			f := Check(g, func(int) bool { return false }, WithSeed(1))
+			if f == nil || f.Shrunk != tc.target {
+				t.Fatalf("Int %s: expected to shrink to %d, got %v", tc.name, tc.target, f)
+			}
+		})
+	}
+
+	// shrinking over the whole range of int, where the distance overflows int
+	for _, v := range []int{math.MinInt, math.MaxInt} {
+		for _, s := range intTree(v, 0).shrinks() {
+			if (v < 0 && (s.value < v || s.value > 0)) || (v > 0 && (s.value > v || s.value < 0)) {
+				t.Fatalf("intTree: shrink %d of %d is not between it and 0", s.value, v)
+			}
+		}
+	}
+	for _, s := range intTree(math.MaxInt, -1).shrinks() {
+		if s.value < -1 {
+			t.Fatalf("intTree: shrink %d of max int towards -1 overflowed", s.value)
+		}
+	}
+	if res := intTree(math.MinInt, math.MaxInt).shrinks(); res[0].value != math.MaxInt || res[len(res)-1].value != math.MinInt+1 {
+		t.Fatalf("intTree: expected shrinks from the target to the closest value, got %d ... %d", res[0].value, res[len(res)-1].value)
+	}
+}
+
+func Test_Float64_Boundaries(t *testing.T) {
+	f := Check(Float64(0.5, 0.9), func(x float64) bool { return x >= 0.5 && x < 0.6 }, WithSeed(1))
+	if f == nil || f.Shrunk < 0.6 || f.Shrunk >= 0.9 {
+		t.Fatalf("Float64: expected to shrink within [0.6, 0.9), got %v", f)
+	}
+
+	tt := []struct {
+		name     string
+		min, max float64
+		target   float64
+	}{
+		{name: "negative", min: -2, max: -1, target: math.Nextafter(-1, -2)},
+		{name: "up to zero", min: -1, max: 0, target: math.Nextafter(0, -1)},
+		{name: "beyond int64", min: 1e19, max: 1e20, target: 1e19},
+		{name: "single value", min: -1, max: -1, target: -1},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f := Check(Float64(tc.min, tc.max), func(float64) bool { return false }, WithSeed(1))
+			if f == nil || f.Shrunk != tc.target {
+				t.Fatalf("Float64 %s: expected to shrink to %v, got %v", tc.name, tc.target, f)
+			}
+		})
+	}
+
+	// every shrink stays in range, even for floats too big for int64
+	for _, v := range []float64{1e19 + 12345.5, 0.75} {
+		min, max := 0.5, 2e19
+		for _, s := range floatTree(v, min, min, max, 0).shrinks() {
+			if s.value < min || s.value >= max {
+				t.Fatalf("floatTree: shrink %v of %v is out of [%v, %v)", s.value, v, min, max)
+			}
+		}
+	}
+}
+
+func Test_Check_Shrinks(t *testing.T) {
+	type point struct{ X, Y int }
+
+	tt := []struct {
+		name     string
+		check    func() any
+		expected any
+	}{
+		{
+			name: "int shrinks to the boundary",
+			check: func() any {
+				return Check(Int(0, 1000), func(i int) bool { return i < 100 }, WithSeed(1)).Shrunk
+			},
+			expected: 100,
+		},
+		{
+			name: "int shrinks towards the range",
+			check: func() any {
+				return Check(Int(-1000, -10), func(i int) bool { return i > -500 }, WithSeed(1)).Shrunk
+			},
+			expected: -500,
+		},
+		{
+			name: "slice shrinks to a single element",
+			check: func() any {
+				return Check(SliceOf(Ints()), func(s []int) bool {
+					return slices.FindIndex(s, func(i int) bool { return i > 5 }) == -1
+				}, WithSeed(1)).Shrunk
+			},
+			expected: []int{6},
+		},
+		{
+			name: "slice shrinks to the shortest length",
+			check: func() any {
+				return Check(SliceOf(Ints()), func(s []int) bool { return len(s) < 3 }, WithSeed(1)).Shrunk
+			},
+			expected: []int{0, 0, 0},
+		},
+		{
+			name: "string shrinks to the first letters",
+			check: func() any {
+				return Check(String(), func(s string) bool { return !strings.Contains(s, "z") }, WithSeed(1)).Shrunk
+			},
+			expected: "z",
+		},
+		{
+			name: "struct shrinks every field",
+			check: func() any {
+				gen := Map2(Ints(), Ints(), func(x, y int) point { return point{x, y} })
+				return Check(gen, func(p point) bool { return p.X < 10 || p.Y < 20 }, WithSeed(1)).Shrunk
+			},
+			expected: point{10, 20},
+		},
+		{
+			name: "map shrinks keys and values",
+			check: func() any {
+				return Check(MapOf(String(), Ints()), func(m map[string]int) bool {
+					return maps.FindKeyBy(m, func(v int) bool { return v >= 10 }) == nil
+				}, WithSeed(1)).Shrunk
+			},
+			expected: map[string]int{"": 10},
+		},
+		{
+			name: "panic is a failure",
+			check: func() any {
+				f := Check(SliceOf(Ints()), func(s []int) bool { return s[0] >= 0 }, WithSeed(1))
+				if f.Panic == nil {
+					return "no panic"
+				}
+				return f.Shrunk
+			},
+			expected: []int{},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			res := tc.check()
+
+			if !reflect.DeepEqual(res, tc.expected) {
+				t.Fatalf(`Check %s: expected
+				%#v, got
+				%#v`, tc.name, tc.expected, res)
+			}
+		})
+	}
+}
+
+func Test_Check_Options(t *testing.T) {
+	runs := 0
+	f := Check(Ints(), func(int) bool { runs++; return true }, WithRuns(10))
+	if f != nil || runs != 10 {
+		t.Fatalf("Check: expected 10 successful runs, got %d, %v", runs, f)
+	}
+
+	sizes := map[int]bool{}
+	Check(Ints(), func(i int) bool { sizes[i] = true; return true }, WithMaxSize(0))
+	if len(sizes) != 1 || !sizes[0] {
+		t.Fatalf("Check: expected only zeros with max size 0, got %v", sizes)
+	}
+
+	sizes = map[int]bool{}
+	Check(Ints(), func(i int) bool { sizes[i] = true; return true }, WithMaxSize(-1))
+	if len(sizes) != 1 || !sizes[0] {
+		t.Fatalf("Check: expected negative max size to be treated as 0, got %v", sizes)
+	}
+
+	gen := Int(0, 1<<30)
+	a := Check(gen, func(i int) bool { return i < 1<<20 }, WithSeed(42), WithMaxShrinks(2))
+	b := Check(gen, func(i int) bool { return i < 1<<20 }, WithSeed(42), WithMaxShrinks(2))
+	if a == nil || !reflect.DeepEqual(a, b) || a.Shrinks != 2 {
+		t.Fatalf("Check: expected reproducible failures with 2 shrinks, got %v and %v", a, b)
+	}
+	if !strings.Contains(a.String(), "seed 42") {
+		t.Fatalf("Failure: seed should be reported, got %s", a)
+	}
+}
+
+func Test_ForAll(t *testing.T) {
+	ForAll(t, SliceOf(Ints()), func(s []int) bool {
+		doubled := slices.Map(s, func(v int, _ int, _ []int) int { return v * 2 })
+		return len(doubled) == len(s)
+	})
+
+	ForAll(t, Map2(SliceOf(Ints()), Ints(), func(s []int, n int) [2][]int {
+		return [2][]int{s, slices.Filter(s, func(v int, _ int, _ []int) bool { return v > n })}
+	}), func(in [2][]int) bool {
+		return len(in[1]) <= len(in[0])
+	})
+}
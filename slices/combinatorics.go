@@ -0,0 +1,209 @@
+package slices
+
+// Permutations returns all orderings of the elements of a slice, in lexicographic order of positions.
+// Elements are treated as distinct by their positions, even if they are equal.
+// The result grows factorially, use ForEachPermutation not to keep all of them in memory.
+func Permutations[T any](in []T) [][]T {
+	if in == nil {
+		return nil
+	}
+	return collect(func(yield func([]T) bool) { ForEachPermutation(in, yield) })
+}
+
+// ForEachPermutation calls a function for every permutation of a slice, in the order of Permutations,
+// until it returns false. The slice passed to the function is reused between calls, copy it to keep it.
+func ForEachPermutation[T any](in []T, fn func([]T) bool) {
+	idx := make([]int, len(in))
+	for i := range idx {
+		idx[i] = i
+	}
+	buf := make([]T, len(in))
+
+	for {
+		if !fn(pick(buf, in, idx)) {
+			return
+		}
+
+		// the next permutation of positions: find the last ascent, swap it with the smallest bigger position after it
+		// and reverse the tail
+		i := len(idx) - 2
+		for i >= 0 && idx[i] > idx[i+1] {
+			i--
+		}
+		if i < 0 {
+			return
+		}
+		j := len(idx) - 1
+		for idx[j] < idx[i] {
+			j--
+		}
+		idx[i], idx[j] = idx[j], idx[i]
+		ReverseInPlace(idx[i+1:])
+	}
+}
+
+// Combinations returns all ways to choose k elements of a slice regardless of their order,
+// keeping the original order of elements in every combination.
+func Combinations[T any](in []T, k int) [][]T {
+	if in == nil {
+		return nil
+	}
+	return collect(func(yield func([]T) bool) { ForEachCombination(in, k, yield) })
+}
+
+// ForEachCombination calls a function for every combination of k elements of a slice, in the order of Combinations,
+// until it returns false. The slice passed to the function is reused between calls, copy it to keep it.
+func ForEachCombination[T any](in []T, k int, fn func([]T) bool) {
+	n := len(in)
+	if k < 0 || k > n {
+		return
+	}
+
+	idx := make([]int, k)
+	for i := range idx {
+		idx[i] = i
+	}
+	buf := make([]T, k)
+
+	for {
+		if !fn(pick(buf, in, idx)) {
+			return
+		}
+
+		// the rightmost position which can move right, the positions after it follow it
+		i := k - 1
+		for i >= 0 && idx[i] == n-k+i {
+			i--
+		}
+		if i < 0 {
+			return
+		}
+		idx[i]++
+		for j := i + 1; j < k; j++ {
+			idx[j] = idx[j-1] + 1
+		}
+	}
+}
+
+// CombinationsWithReplacement returns all ways to choose k elements of a slice regardless of their order,
+// when every element can be chosen several times.
+func CombinationsWithReplacement[T any](in []T, k int) [][]T {
+	if in == nil {
+		return nil
+	}
+	return collect(func(yield func([]T) bool) { ForEachCombinationWithReplacement(in, k, yield) })
+}
+
+// ForEachCombinationWithReplacement calls a function for every combination of CombinationsWithReplacement
+// until it returns false. The slice passed to the function is reused between calls, copy it to keep it.
+func ForEachCombinationWithReplacement[T any](in []T, k int, fn func([]T) bool) {
+	n := len(in)
+	if k < 0 || (n == 0 && k > 0) {
+		return
+	}
+
+	idx := make([]int, k)
+	buf := make([]T, k)
+
+	for {
+		if !fn(pick(buf, in, idx)) {
+			return
+		}
+
+		i := k - 1
+		for i >= 0 && idx[i] == n-1 {
+			i--
+		}
+		if i < 0 {
+			return
+		}
+		idx[i]++
+		for j := i + 1; j < k; j++ {
+			idx[j] = idx[i]
+		}
+	}
+}
+
+// PowerSet returns all subsets of the elements of a slice, from the empty one to the whole slice,
+// shorter ones first. The result has 2^len(in) subsets, use ForEachSubset not to keep all of them in memory.
+func PowerSet[T any](in []T) [][]T {
+	if in == nil {
+		return nil
+	}
+	return collect(func(yield func([]T) bool) { ForEachSubset(in, yield) })
+}
+
+// ForEachSubset calls a function for every subset of a slice, in the order of PowerSet, until it returns false.
+// The slice passed to the function is reused between calls, copy it to keep it.
+func ForEachSubset[T any](in []T, fn func([]T) bool) {
+	for k := 0; k <= len(in); k++ {
+		stopped := false
+		ForEachCombination(in, k, func(c []T) bool {
+			stopped = !fn(c)
+			return !stopped
+		})
+		if stopped {
+			return
+		}
+	}
+}
+
+// CartesianProduct returns all ways to take one element of every given slice, the last slice changing fastest.
+// There are no results if any of the slices is empty.
+func CartesianProduct[T any](in ...[]T) [][]T {
+	if in == nil {
+		return nil
+	}
+	return collect(func(yield func([]T) bool) { ForEachProduct(yield, in...) })
+}
+
+// ForEachProduct calls a function for every element of CartesianProduct of given slices until it returns false.
+// The slice passed to the function is reused between calls, copy it to keep it.
+func ForEachProduct[T any](fn func([]T) bool, in ...[]T) {
+	for _, s := range in {
+		if len(s) == 0 {
+			return
+		}
+	}
+
+	idx := make([]int, len(in))
+	buf := make([]T, len(in))
+
+	for {
+		for i, j := range idx {
+			buf[i] = in[i][j]
+		}
+		if !fn(buf) {
+			return
+		}
+
+		// count like an odometer
+		i := len(idx) - 1
+		for i >= 0 && idx[i] == len(in[i])-1 {
+			idx[i] = 0
+			i--
+		}
+		if i < 0 {
+			return
+		}
+		idx[i]++
+	}
+}
+
+// pick fills buf with elements of in at given positions.
+func pick[T any](buf, in []T, idx []int) []T {
+	for i, j := range idx {
+		buf[i] = in[j]
+	}
+	return buf
+}
+
+// collect copies all slices a ForEach function calls its callback with.
+func collect[T any](forEach func(yield func([]T) bool)) [][]T {
+	res := [][]T{}
+	forEach(func(s []T) bool {
+		res = append(res, append(make([]T, 0, len(s)), s...))
+		return true
+	})
+	return res
+}
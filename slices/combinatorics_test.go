@@ -0,0 +1,246 @@
+package slices
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_Permutations(t *testing.T) {
+	tt := []struct {
+		name     string
+		in       []int
+		expected [][]int
+	}{
+		{
+			name:     "three elements",
+			in:       []int{1, 2, 3},
+			expected: [][]int{{1, 2, 3}, {1, 3, 2}, {2, 1, 3}, {2, 3, 1}, {3, 1, 2}, {3, 2, 1}},
+		},
+		{
+			name:     "equal elements are distinct",
+			in:       []int{1, 1},
+			expected: [][]int{{1, 1}, {1, 1}},
+		},
+		{
+			name:     "empty - one empty permutation",
+			in:       []int{},
+			expected: [][]int{{}},
+		},
+		{
+			name:     "nil in - nil out",
+			in:       nil,
+			expected: nil,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			res := Permutations(tc.in)
+			if !reflect.DeepEqual(res, tc.expected) {
+				t.Fatalf(`Permutations %s: expected
+				%#v, got
+				%#v`, tc.name, tc.expected, res)
+			}
+		})
+	}
+
+	if n := len(Permutations([]int{1, 2, 3, 4, 5})); n != 120 {
+		t.Fatalf("Permutations: expected 120 permutations of 5 elements, got %d", n)
+	}
+}
+
+func Test_Combinations(t *testing.T) {
+	tt := []struct {
+		name     string
+		in       []string
+		k        int
+		expected [][]string
+	}{
+		{
+			name:     "2 of 4",
+			in:       []string{"a", "b", "c", "d"},
+			k:        2,
+			expected: [][]string{{"a", "b"}, {"a", "c"}, {"a", "d"}, {"b", "c"}, {"b", "d"}, {"c", "d"}},
+		},
+		{
+			name:     "all",
+			in:       []string{"a", "b"},
+			k:        2,
+			expected: [][]string{{"a", "b"}},
+		},
+		{
+			name:     "none - one empty combination",
+			in:       []string{"a", "b"},
+			k:        0,
+			expected: [][]string{{}},
+		},
+		{
+			name:     "more than there is",
+			in:       []string{"a", "b"},
+			k:        3,
+			expected: [][]string{},
+		},
+		{
+			name:     "negative",
+			in:       []string{"a", "b"},
+			k:        -1,
+			expected: [][]string{},
+		},
+		{
+			name:     "nil in - nil out",
+			in:       nil,
+			k:        1,
+			expected: nil,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			res := Combinations(tc.in, tc.k)
+			if !reflect.DeepEqual(res, tc.expected) {
+				t.Fatalf(`Combinations %s: expected
+				%#v, got
+				%#v`, tc.name, tc.expected, res)
+			}
+		})
+	}
+}
+
+func Test_CombinationsWithReplacement(t *testing.T) {
+	tt := []struct {
+		name     string
+		in       []int
+		k        int
+		expected [][]int
+	}{
+		{
+			name:     "2 of 3",
+			in:       []int{1, 2, 3},
+			k:        2,
+			expected: [][]int{{1, 1}, {1, 2}, {1, 3}, {2, 2}, {2, 3}, {3, 3}},
+		},
+		{
+			name:     "more than there is",
+			in:       []int{1, 2},
+			k:        3,
+			expected: [][]int{{1, 1, 1}, {1, 1, 2}, {1, 2, 2}, {2, 2, 2}},
+		},
+		{
+			name:     "empty",
+			in:       []int{},
+			k:        2,
+			expected: [][]int{},
+		},
+		{
+			name:     "nil in - nil out",
+			in:       nil,
+			k:        2,
+			expected: nil,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			res := CombinationsWithReplacement(tc.in, tc.k)
+			if !reflect.DeepEqual(res, tc.expected) {
+				t.Fatalf(`CombinationsWithReplacement %s: expected
+				%#v, got
+				%#v`, tc.name, tc.expected, res)
+			}
+		})
+	}
+}
+
+func Test_PowerSet(t *testing.T) {
+	res := PowerSet([]int{1, 2, 3})
+	expected := [][]int{{}, {1}, {2}, {3}, {1, 2}, {1, 3}, {2, 3}, {1, 2, 3}}
+	if !reflect.DeepEqual(res, expected) {
+		t.Fatalf(`PowerSet: expected
+				%#v, got
+				%#v`, expected, res)
+	}
+
+	if res := PowerSet([]int{}); !reflect.DeepEqual(res, [][]int{{}}) {
+		t.Fatalf("PowerSet: expected only the empty set, got %#v", res)
+	}
+	if res := PowerSet([]int(nil)); res != nil {
+		t.Fatalf("PowerSet: nil in - nil out, got %#v", res)
+	}
+}
+
+func Test_CartesianProduct(t *testing.T) {
+	tt := []struct {
+		name     string
+		in       [][]string
+		expected [][]string
+	}{
+		{
+			name:     "two slices",
+			in:       [][]string{{"linux", "darwin"}, {"amd64", "arm64"}},
+			expected: [][]string{{"linux", "amd64"}, {"linux", "arm64"}, {"darwin", "amd64"}, {"darwin", "arm64"}},
+		},
+		{
+			name:     "one slice",
+			in:       [][]string{{"a", "b"}},
+			expected: [][]string{{"a"}, {"b"}},
+		},
+		{
+			name:     "an empty slice - no results",
+			in:       [][]string{{"a", "b"}, {}},
+			expected: [][]string{},
+		},
+		{
+			name:     "nil in - nil out",
+			in:       nil,
+			expected: nil,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			res := CartesianProduct(tc.in...)
+			if !reflect.DeepEqual(res, tc.expected) {
+				t.Fatalf(`CartesianProduct %s: expected
+				%#v, got
+				%#v`, tc.name, tc.expected, res)
+			}
+		})
+	}
+}
+
+func Test_ForEach_Stop(t *testing.T) {
+	in := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	tt := []struct {
+		name    string
+		forEach func(fn func([]int) bool)
+	}{
+		{"ForEachPermutation", func(fn func([]int) bool) { ForEachPermutation(in, fn) }},
+		{"ForEachCombination", func(fn func([]int) bool) { ForEachCombination(in, 5, fn) }},
+		{"ForEachCombinationWithReplacement", func(fn func([]int) bool) { ForEachCombinationWithReplacement(in, 5, fn) }},
+		{"ForEachSubset", func(fn func([]int) bool) { ForEachSubset(in, fn) }},
+		{"ForEachProduct", func(fn func([]int) bool) { ForEachProduct(fn, in, in, in) }},
+	}
+
+	for _, tc := range tt {
+		calls := 0
+		tc.forEach(func([]int) bool {
+			calls++
+			return calls < 3
+		})
+		if calls != 3 {
+			t.Fatalf("%s: expected to stop after 3 calls, got %d", tc.name, calls)
+		}
+	}
+}
+
+func Test_ForEachCombination_Count(t *testing.T) {
+	calls := 0
+	ForEachCombination(make([]int, 20), 10, func([]int) bool {
+		calls++
+		return true
+	})
+	if calls != 184756 {
+		t.Fatalf("ForEachCombination: expected 184756 combinations of 10 of 20, got %d", calls)
+	}
+}
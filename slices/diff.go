@@ -0,0 +1,254 @@
+package slices
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrPatchMismatch is returned by ApplyPatch when an edit script does not match the given slice.
+var ErrPatchMismatch = errors.New("slices: patch does not match")
+
+// EditOp is a kind of Edit.
+type EditOp int
+
+const (
+	// EditEqual keeps an element which is in both slices.
+	EditEqual EditOp = iota
+	// EditDelete removes an element of the first slice.
+	EditDelete
+	// EditInsert adds an element of the second slice.
+	EditInsert
+)
+
+// String returns a one-character representation of an operation, like in unified diffs.
+func (op EditOp) String() string {
+	switch op {
+	case EditEqual:
+		return " "
+	case EditDelete:
+		return "-"
+	case EditInsert:
+		return "+"
+	default:
+		return "?"
+	}
+}
+
+// Edit is a single step of an edit script.
+type Edit[T any] struct {
+	Op    EditOp
+	Value T
+}
+
+// Diff produces a minimal edit script turning slice a into slice b using Myers' algorithm (linear space variant).
+// Every element of a is either deleted or kept, every element of b is either inserted or kept.
+// In a row of changes deletions go before insertions.
+func Diff[T any](a, b []T, eq func(T, T) bool) []Edit[T] {
+	if len(a) == 0 && len(b) == 0 {
+		return nil
+	}
+
+	d := differ[T]{
+		a:         a,
+		b:         b,
+		eq:        eq,
+		deletedA:  make([]bool, len(a)),
+		insertedB: make([]bool, len(b)),
+	}
+	d.diff(0, len(a), 0, len(b))
+
+	script := make([]Edit[T], 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && d.deletedA[i]:
+			script = append(script, Edit[T]{Op: EditDelete, Value: a[i]})
+			i++
+		case j < len(b) && d.insertedB[j]:
+			script = append(script, Edit[T]{Op: EditInsert, Value: b[j]})
+			j++
+		default:
+			script = append(script, Edit[T]{Op: EditEqual, Value: a[i]})
+			i++
+			j++
+		}
+	}
+
+	return script
+}
+
+// LongestCommonSubsequence returns the longest sequence of elements which are in both slices in the same order.
+// Elements are taken from slice a.
+func LongestCommonSubsequence[T any](a, b []T, eq func(T, T) bool) []T {
+	script := Diff(a, b, eq)
+	if script == nil {
+		return nil
+	}
+
+	out := make([]T, 0, len(script))
+	for _, e := range script {
+		if e.Op == EditEqual {
+			out = append(out, e.Value)
+		}
+	}
+
+	return out
+}
+
+// ApplyPatch applies an edit script made by Diff to a slice and returns a new slice. Original slice stays untouched.
+// Kept and deleted elements are checked with eq, ErrPatchMismatch is returned if they do not match the slice.
+func ApplyPatch[T any](in []T, script []Edit[T], eq func(T, T) bool) ([]T, error) {
+	if in == nil && script == nil {
+		return nil, nil
+	}
+
+	out := make([]T, 0, len(in))
+	i := 0
+	for pos, e := range script {
+		switch e.Op {
+		case EditInsert:
+			out = append(out, e.Value)
+			continue
+		case EditEqual, EditDelete:
+		default:
+			return nil, fmt.Errorf("%w: unknown operation %d at position %d", ErrPatchMismatch, e.Op, pos)
+		}
+
+		if i >= len(in) {
+			return nil, fmt.Errorf("%w: edit at position %d is out of range", ErrPatchMismatch, pos)
+		}
+		if !eq(in[i], e.Value) {
+			return nil, fmt.Errorf("%w: element %d differs from edit at position %d", ErrPatchMismatch, i, pos)
+		}
+		if e.Op == EditEqual {
+			out = append(out, in[i])
+		}
+		i++
+	}
+
+	if i != len(in) {
+		return nil, fmt.Errorf("%w: %d elements are not covered", ErrPatchMismatch, len(in)-i)
+	}
+
+	return out, nil
+}
+
+type differ[T any] struct {
+	a, b      []T
+	eq        func(T, T) bool
+	deletedA  []bool
+	insertedB []bool
+}
+
+func (d *differ[T]) diff(aLo, aHi, bLo, bHi int) {
+	for aLo < aHi && bLo < bHi && d.eq(d.a[aLo], d.b[bLo]) {
+		aLo++
+		bLo++
+	}
+	for aLo < aHi && bLo < bHi && d.eq(d.a[aHi-1], d.b[bHi-1]) {
+		aHi--
+		bHi--
+	}
+
+	x, y, ok := 0, 0, false
+	if aLo < aHi && bLo < bHi {
+		x, y, ok = d.middleSnake(aLo, aHi, bLo, bHi)
+	}
+	if !ok {
+		for i := aLo; i < aHi; i++ {
+			d.deletedA[i] = true
+		}
+		for j := bLo; j < bHi; j++ {
+			d.insertedB[j] = true
+		}
+		return
+	}
+
+	d.diff(aLo, x, bLo, y)
+	d.diff(x, aHi, y, bHi)
+}
+
+// middleSnake finds a point to split the edit graph of a[aLo:aHi] and b[bLo:bHi] in two,
+// searching for the shortest path from both ends at once.
+// Both ranges are not empty and their first and last elements differ.
+// If there is no such point, the ranges have nothing in common.
+func (d *differ[T]) middleSnake(aLo, aHi, bLo, bHi int) (int, int, bool) {
+	n, m := aHi-aLo, bHi-bLo
+	maxD := (n + m + 1) / 2
+	offset := maxD
+	vf := make([]int, 2*maxD+2)
+	vb := make([]int, 2*maxD+2)
+	for i := range vf {
+		vf[i], vb[i] = -1, -1
+	}
+	vf[offset+1], vb[offset+1] = 0, 0
+
+	delta := n - m
+	front := delta%2 != 0
+	// k ranges of the diagonals which went out of the graph and shouldn't be checked anymore
+	kfStart, kfEnd, kbStart, kbEnd := 0, 0, 0, 0
+
+	for D := 0; D < maxD; D++ {
+		for k := -D + kfStart; k <= D-kfEnd; k += 2 {
+			ki := offset + k
+			var x int
+			if k == -D || (k != D && vf[ki-1] < vf[ki+1]) {
+				x = vf[ki+1]
+			} else {
+				x = vf[ki-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && d.eq(d.a[aLo+x], d.b[bLo+y]) {
+				x++
+				y++
+			}
+			vf[ki] = x
+
+			switch {
+			case x > n:
+				kfEnd += 2
+			case y > m:
+				kfStart += 2
+			case front:
+				kb := offset + delta - k
+				if kb >= 0 && kb < len(vb) && vb[kb] != -1 && x >= n-vb[kb] {
+					return aLo + x, bLo + y, true
+				}
+			}
+		}
+
+		for k := -D + kbStart; k <= D-kbEnd; k += 2 {
+			ki := offset + k
+			var x int
+			if k == -D || (k != D && vb[ki-1] < vb[ki+1]) {
+				x = vb[ki+1]
+			} else {
+				x = vb[ki-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && d.eq(d.a[aHi-x-1], d.b[bHi-y-1]) {
+				x++
+				y++
+			}
+			vb[ki] = x
+
+			switch {
+			case x > n:
+				kbEnd += 2
+			case y > m:
+				kbStart += 2
+			case !front:
+				kf := offset + delta - k
+				if kf >= 0 && kf < len(vf) && vf[kf] != -1 {
+					xf := vf[kf]
+					yf := offset + xf - kf
+					if xf >= n-x {
+						return aLo + xf, bLo + yf, true
+					}
+				}
+			}
+		}
+	}
+
+	return 0, 0, false
+}
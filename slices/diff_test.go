@@ -0,0 +1,197 @@
+package slices
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func eqInt(a, b int) bool { return a == b }
+
+func Test_Diff(t *testing.T) {
+	tt := []struct {
+		name     string
+		a        []string
+		b        []string
+		expected string
+	}{
+		{
+			name:     "classic example",
+			a:        strings.Split("ABCABBA", ""),
+			b:        strings.Split("CBABAC", ""),
+			expected: "-A+C B-C A B-B A+C",
+		},
+		{
+			name:     "equal",
+			a:        []string{"a", "b"},
+			b:        []string{"a", "b"},
+			expected: " a b",
+		},
+		{
+			name:     "all inserted",
+			a:        nil,
+			b:        []string{"a", "b"},
+			expected: "+a+b",
+		},
+		{
+			name:     "all deleted",
+			a:        []string{"a", "b"},
+			b:        []string{},
+			expected: "-a-b",
+		},
+		{
+			name:     "nothing in common",
+			a:        []string{"a", "b"},
+			b:        []string{"c"},
+			expected: "-a-b+c",
+		},
+		{
+			name:     "nil in - nil out",
+			a:        nil,
+			b:        nil,
+			expected: "",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			script := Diff(tc.a, tc.b, func(x, y string) bool { return x == y })
+
+			var sb strings.Builder
+			for _, e := range script {
+				sb.WriteString(e.Op.String() + e.Value)
+			}
+			res := sb.String()
+
+			if res != tc.expected {
+				t.Fatalf(`Diff %s: expected
+				%q, got
+				%q`, tc.name, tc.expected, res)
+			}
+		})
+	}
+}
+
+// lcsLength is a straightforward O(N*M) dynamic programming solution to check Diff against.
+func lcsLength(a, b []int) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			switch {
+			case a[i-1] == b[j-1]:
+				cur[j] = prev[j-1] + 1
+			case prev[j] > cur[j-1]:
+				cur[j] = prev[j]
+			default:
+				cur[j] = cur[j-1]
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func randomInts(r *rand.Rand, n, alphabet int) []int {
+	out := make([]int, n)
+	for i := range out {
+		out[i] = r.Intn(alphabet)
+	}
+	return out
+}
+
+func Test_Diff_IsMinimal(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+
+	for i := 0; i < 2000; i++ {
+		a := randomInts(r, r.Intn(20), 1+r.Intn(5))
+		b := randomInts(r, r.Intn(20), 1+r.Intn(5))
+
+		script := Diff(a, b, eqInt)
+
+		lcs := LongestCommonSubsequence(a, b, eqInt)
+		if len(lcs) != lcsLength(a, b) {
+			t.Fatalf("Diff %v -> %v: expected LCS of length %d, got %v", a, b, lcsLength(a, b), lcs)
+		}
+		if len(script) != len(a)+len(b)-len(lcs) {
+			t.Fatalf("Diff %v -> %v: script is not consistent with LCS: %v", a, b, script)
+		}
+
+		res, err := ApplyPatch(a, script, eqInt)
+		if err != nil {
+			t.Fatalf("ApplyPatch %v -> %v: unexpected error %v", a, b, err)
+		}
+		if !reflect.DeepEqual(res, b) {
+			t.Fatalf("ApplyPatch %v -> %v: got %v", a, b, res)
+		}
+	}
+}
+
+func Test_LongestCommonSubsequence(t *testing.T) {
+	res := LongestCommonSubsequence([]int{1, 2, 3, 4, 1}, []int{3, 4, 1, 2, 1, 3}, eqInt)
+	expected := []int{1, 2, 3}
+	if !reflect.DeepEqual(res, expected) {
+		t.Fatalf("LongestCommonSubsequence: expected %#v, got %#v", expected, res)
+	}
+
+	if res := LongestCommonSubsequence[int](nil, nil, eqInt); res != nil {
+		t.Fatalf("LongestCommonSubsequence: nil in - nil out, got %#v", res)
+	}
+}
+
+func Test_ApplyPatch_Mismatch(t *testing.T) {
+	script := Diff([]int{1, 2, 3}, []int{1, 3, 4}, eqInt)
+
+	tt := []struct {
+		name string
+		in   []int
+	}{
+		{name: "different element", in: []int{1, 5, 3}},
+		{name: "too short", in: []int{1, 2}},
+		{name: "too long", in: []int{1, 2, 3, 4}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ApplyPatch(tc.in, script, eqInt)
+			if !errors.Is(err, ErrPatchMismatch) {
+				t.Fatalf("ApplyPatch %s: expected ErrPatchMismatch, got %v", tc.name, err)
+			}
+		})
+	}
+}
+
+func benchmarkDiff(b *testing.B, changeRate float64) {
+	r := rand.New(rand.NewSource(1))
+	x := randomInts(r, 10000, 1000)
+	y := make([]int, 0, len(x))
+	for _, v := range x {
+		switch p := r.Float64(); {
+		case p < changeRate/2:
+			// deleted
+		case p < changeRate:
+			y = append(y, r.Intn(1000))
+		default:
+			y = append(y, v)
+		}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Diff(x, y, eqInt)
+	}
+}
+
+func Benchmark_Diff_10k_1PercentChanged(b *testing.B) {
+	benchmarkDiff(b, 0.01)
+}
+
+func Benchmark_Diff_10k_10PercentChanged(b *testing.B) {
+	benchmarkDiff(b, 0.1)
+}
+
+func Benchmark_Diff_10k_AllChanged(b *testing.B) {
+	benchmarkDiff(b, 1)
+}
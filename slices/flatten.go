@@ -0,0 +1,113 @@
+package slices
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrFlattenType is returned by FlattenDeep when a nested value is neither a slice nor a value of the requested type.
+var ErrFlattenType = errors.New("slices: unexpected type to flatten")
+
+// Flatten concatenates slices of a slice into one slice, the opposite of Chunk.
+func Flatten[T any](in [][]T) []T {
+	if in == nil {
+		return nil
+	}
+
+	n := 0
+	for _, s := range in {
+		n += len(s)
+	}
+
+	res := make([]T, 0, n)
+	for _, s := range in {
+		res = append(res, s...)
+	}
+
+	return res
+}
+
+// FlatMap creates a slice by applying a function returning slices to every element of a given slice
+// and concatenating the results.
+func FlatMap[T, Y any](in []T, convert func(T, int, []T) []Y) []Y {
+	if in == nil {
+		return nil
+	}
+
+	res := make([]Y, 0, len(in))
+	for i, elem := range in {
+		res = append(res, convert(elem, i, in)...)
+	}
+
+	return res
+}
+
+// FlattenDeep concatenates slices (and arrays) nested at any depth, like [][][]int or []any{1, []any{2, 3}},
+// into one slice of values of type T. ErrFlattenType is returned if a value is neither a slice nor a T.
+// With an interface type T, all nested slices are flattened, so they are never values.
+func FlattenDeep[T any](in any) ([]T, error) {
+	v := reflect.ValueOf(in)
+	if !v.IsValid() || (v.Kind() == reflect.Slice && v.IsNil()) {
+		return nil, nil
+	}
+
+	res := []T{}
+	if err := flattenDeep(v, reflect.TypeOf((*T)(nil)).Elem(), &res, nil); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// flattenDeep appends values of v to res, path keeps indexes of v to report errors.
+func flattenDeep[T any](v reflect.Value, typ reflect.Type, res *[]T, path []int) error {
+	if v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			if typ.Kind() != reflect.Interface {
+				return fmt.Errorf("%w: nil at %s", ErrFlattenType, formatPath(path))
+			}
+			var zero T
+			*res = append(*res, zero)
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	if typ.Kind() != reflect.Interface && v.Type().AssignableTo(typ) {
+		*res = append(*res, assign[T](v, typ))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := flattenDeep(v.Index(i), typ, res, append(path, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if v.Type().AssignableTo(typ) {
+		*res = append(*res, assign[T](v, typ))
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s at %s", ErrFlattenType, v.Type(), formatPath(path))
+}
+
+func formatPath(path []int) string {
+	res := "in"
+	for _, i := range path {
+		res += fmt.Sprintf("[%d]", i)
+	}
+	return res
+}
+
+// assign converts a value assignable to T, which can be a different named type.
+func assign[T any](v reflect.Value, typ reflect.Type) T {
+	res := reflect.New(typ).Elem()
+	res.Set(v)
+	return res.Interface().(T)
+}
@@ -0,0 +1,139 @@
+package slices
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_Flatten(t *testing.T) {
+	tt := []struct {
+		name     string
+		in       [][]int
+		expected []int
+	}{
+		{
+			name:     "chunks",
+			in:       [][]int{{1, 2}, {3}, nil, {}, {4, 5}},
+			expected: []int{1, 2, 3, 4, 5},
+		},
+		{
+			name:     "empty slices",
+			in:       [][]int{{}, nil},
+			expected: []int{},
+		},
+		{
+			name:     "nil in - nil out",
+			in:       nil,
+			expected: nil,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			res := Flatten(tc.in)
+			if !reflect.DeepEqual(res, tc.expected) {
+				t.Fatalf(`Flatten %s: expected
+				%#v, got
+				%#v`, tc.name, tc.expected, res)
+			}
+		})
+	}
+
+	in := []int{1, 2, 3, 4, 5}
+	if res := Flatten(Chunk(in, 2)); !reflect.DeepEqual(res, in) {
+		t.Fatalf("Flatten: expected Chunk to be reverted, got %#v", res)
+	}
+}
+
+func Test_FlatMap(t *testing.T) {
+	res := FlatMap([]string{"a b", "", "c"}, func(s string, _ int, _ []string) []string { return strings.Fields(s) })
+
+	expected := []string{"a", "b", "c"}
+	if !reflect.DeepEqual(res, expected) {
+		t.Fatalf(`FlatMap: expected
+				%#v, got
+				%#v`, expected, res)
+	}
+
+	res = FlatMap([]string{"", ""}, func(s string, _ int, _ []string) []string { return nil })
+	if res == nil || len(res) != 0 {
+		t.Fatalf("FlatMap: expected an empty slice, got %#v", res)
+	}
+
+	if res := FlatMap(nil, func(s string, _ int, _ []string) []string { return []string{s} }); res != nil {
+		t.Fatalf("FlatMap: nil in - nil out, got %#v", res)
+	}
+}
+
+type ints []int
+
+func Test_FlattenDeep(t *testing.T) {
+	res, err := FlattenDeep[int]([][][]int{{{1, 2}, {3}}, {}, {{4}}})
+	if err != nil || !reflect.DeepEqual(res, []int{1, 2, 3, 4}) {
+		t.Fatalf("FlattenDeep: expected [1 2 3 4], got %#v, %v", res, err)
+	}
+
+	res, err = FlattenDeep[int]([]any{1, []any{2, [2]int{3, 4}}, []int{5}})
+	if err != nil || !reflect.DeepEqual(res, []int{1, 2, 3, 4, 5}) {
+		t.Fatalf("FlattenDeep: expected [1 2 3 4 5] from mixed nesting, got %#v, %v", res, err)
+	}
+
+	anyRes, err := FlattenDeep[any]([]any{"a", []any{1, nil}, []string{"b"}})
+	if err != nil || !reflect.DeepEqual(anyRes, []any{"a", 1, nil, "b"}) {
+		t.Fatalf("FlattenDeep: expected [a 1 <nil> b] for any, got %#v, %v", anyRes, err)
+	}
+
+	// slices of the requested type are values, not nesting
+	slicesRes, err := FlattenDeep[ints]([][]ints{{{1}, {2, 3}}, {{4}}})
+	if err != nil || !reflect.DeepEqual(slicesRes, []ints{{1}, {2, 3}, {4}}) {
+		t.Fatalf("FlattenDeep: expected slices of ints, got %#v, %v", slicesRes, err)
+	}
+
+	empty, err := FlattenDeep[int]([][]int{{}, nil})
+	if err != nil || empty == nil || len(empty) != 0 {
+		t.Fatalf("FlattenDeep: expected an empty slice, got %#v, %v", empty, err)
+	}
+
+	for _, in := range []any{nil, [][]int(nil)} {
+		if res, err := FlattenDeep[int](in); res != nil || err != nil {
+			t.Fatalf("FlattenDeep: nil in - nil out, got %#v, %v", res, err)
+		}
+	}
+}
+
+func Test_FlattenDeep_Errors(t *testing.T) {
+	tt := []struct {
+		name     string
+		in       any
+		expected string
+	}{
+		{
+			name:     "a value of another type",
+			in:       []any{1, []any{2, "3"}},
+			expected: "slices: unexpected type to flatten: string at in[1][1]",
+		},
+		{
+			name:     "nil",
+			in:       []any{nil},
+			expected: "slices: unexpected type to flatten: nil at in[0]",
+		},
+		{
+			name:     "not a slice",
+			in:       "1",
+			expected: "slices: unexpected type to flatten: string at in",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := FlattenDeep[int](tc.in)
+			if !errors.Is(err, ErrFlattenType) || err.Error() != tc.expected || res != nil {
+				t.Fatalf(`FlattenDeep %s: expected error
+				%q, got
+				%#v, %v`, tc.name, tc.expected, res, err)
+			}
+		})
+	}
+}
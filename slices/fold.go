@@ -0,0 +1,39 @@
+package slices
+
+// Scan is like Reduce, but returns all intermediate accumulators: the i-th one is the result of reducing
+// elements up to the i-th, so the last one is what Reduce returns. Running totals are a typical use.
+func Scan[T, Y any](in []T, reduce func(Y, T, int) Y, acc Y) []Y {
+	if in == nil {
+		return nil
+	}
+
+	res := make([]Y, len(in))
+	for i, elem := range in {
+		acc = reduce(acc, elem, i)
+		res[i] = acc
+	}
+
+	return res
+}
+
+// ReduceRight is like Reduce, but iterates over a slice from the last element to the first one.
+func ReduceRight[T, Y any](in []T, reduce func(Y, T, int) Y, acc Y) Y {
+	for i := len(in) - 1; i >= 0; i-- {
+		acc = reduce(acc, in[i], i)
+	}
+
+	return acc
+}
+
+// ReduceUntil is like Reduce, but stops as soon as the function returns true along with the accumulator,
+// which is the result then. Elements after it are not visited.
+func ReduceUntil[T, Y any](in []T, reduce func(Y, T, int) (Y, bool), acc Y) Y {
+	for i, elem := range in {
+		var stop bool
+		if acc, stop = reduce(acc, elem, i); stop {
+			break
+		}
+	}
+
+	return acc
+}
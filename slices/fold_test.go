@@ -0,0 +1,101 @@
+package slices
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func Test_Scan(t *testing.T) {
+	tt := []struct {
+		name     string
+		in       []int
+		expected []int
+	}{
+		{
+			name:     "running totals",
+			in:       []int{1, 2, 3, 4},
+			expected: []int{11, 13, 16, 20},
+		},
+		{
+			name:     "empty",
+			in:       []int{},
+			expected: []int{},
+		},
+		{
+			name:     "nil in - nil out",
+			in:       nil,
+			expected: nil,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			res := Scan(tc.in, func(acc int, v int, _ int) int { return acc + v }, 10)
+			if !reflect.DeepEqual(res, tc.expected) {
+				t.Fatalf(`Scan %s: expected
+				%#v, got
+				%#v`, tc.name, tc.expected, res)
+			}
+		})
+	}
+}
+
+func Test_ReduceRight(t *testing.T) {
+	res := ReduceRight([]int{1, 2, 3}, func(acc string, v int, i int) string {
+		return acc + strconv.Itoa(v) + "@" + strconv.Itoa(i) + " "
+	}, "")
+
+	expected := "3@2 2@1 1@0 "
+	if res != expected {
+		t.Fatalf("ReduceRight: expected %q, got %q", expected, res)
+	}
+
+	if res := ReduceRight(nil, func(acc string, v int, _ int) string { return "changed" }, "acc"); res != "acc" {
+		t.Fatalf("ReduceRight: expected the accumulator for nil, got %q", res)
+	}
+}
+
+func Test_ReduceUntil(t *testing.T) {
+	tt := []struct {
+		name          string
+		in            []int
+		expected      int
+		expectedCalls int
+	}{
+		{
+			name:          "stops when the sum is over 5",
+			in:            []int{1, 2, 3, 4, 5},
+			expected:      6,
+			expectedCalls: 3,
+		},
+		{
+			name:          "never stops",
+			in:            []int{1, 2},
+			expected:      3,
+			expectedCalls: 2,
+		},
+		{
+			name:          "nil in - accumulator out",
+			in:            nil,
+			expected:      0,
+			expectedCalls: 0,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := 0
+			res := ReduceUntil(tc.in, func(acc int, v int, _ int) (int, bool) {
+				calls++
+				acc += v
+				return acc, acc > 5
+			}, 0)
+
+			if res != tc.expected || calls != tc.expectedCalls {
+				t.Fatalf("ReduceUntil %s: expected %d after %d calls, got %d after %d calls",
+					tc.name, tc.expected, tc.expectedCalls, res, calls)
+			}
+		})
+	}
+}
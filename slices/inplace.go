@@ -0,0 +1,56 @@
+package slices
+
+// FilterInPlace keeps values filtered by a given function, moving them to the beginning of the slice.
+// It does not allocate: the result shares the backing array with the original slice,
+// and the elements after the result are zeroed to let them be garbage collected. Mutates original slice.
+// The function gets the original slice, elements before the current one may already be moved.
+func FilterInPlace[T any](in []T, filter func(T, int, []T) bool) []T {
+	if in == nil {
+		return nil
+	}
+
+	n := 0
+	for i, elem := range in {
+		if filter(elem, i, in) {
+			in[n] = elem
+			n++
+		}
+	}
+
+	var zero T
+	for i := n; i < len(in); i++ {
+		in[i] = zero
+	}
+
+	return in[:n]
+}
+
+// MapInPlace replaces every element of a slice with the result of a given function. Mutates original slice.
+// The function gets the original slice, elements before the current one are already replaced.
+func MapInPlace[T any](in []T, convert func(T, int, []T) T) []T {
+	for i, elem := range in {
+		in[i] = convert(elem, i, in)
+	}
+
+	return in
+}
+
+// AppendMap is like Map, but appends the results to dst, so it does not allocate when dst has enough capacity.
+func AppendMap[T, Y any](dst []Y, in []T, convert func(T, int, []T) Y) []Y {
+	for i, elem := range in {
+		dst = append(dst, convert(elem, i, in))
+	}
+
+	return dst
+}
+
+// AppendFilter is like Filter, but appends the values to dst, so it does not allocate when dst has enough capacity.
+func AppendFilter[T any](dst []T, in []T, filter func(T, int, []T) bool) []T {
+	for i, elem := range in {
+		if filter(elem, i, in) {
+			dst = append(dst, elem)
+		}
+	}
+
+	return dst
+}
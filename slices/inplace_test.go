@@ -0,0 +1,180 @@
+package slices
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func Test_FilterInPlace(t *testing.T) {
+	tt := []struct {
+		name     string
+		in       []int
+		filter   func(i int, _ int, _ []int) bool
+		expected []int
+	}{
+		{
+			name:     "even values",
+			in:       []int{1, 2, 3, 4, 5, 6, 7, 8, 9},
+			filter:   func(i int, _ int, _ []int) bool { return i%2 == 0 },
+			expected: []int{2, 4, 6, 8},
+		},
+		{
+			name:     "nothing is kept",
+			in:       []int{1, 2, 3},
+			filter:   func(i int, _ int, _ []int) bool { return false },
+			expected: []int{},
+		},
+		{
+			name:     "everything is kept",
+			in:       []int{1, 2, 3},
+			filter:   func(i int, _ int, _ []int) bool { return true },
+			expected: []int{1, 2, 3},
+		},
+		{
+			name:     "nil in - nil out",
+			in:       nil,
+			filter:   func(i int, _ int, _ []int) bool { return true },
+			expected: nil,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			res := FilterInPlace(tc.in, tc.filter)
+			if !reflect.DeepEqual(res, tc.expected) {
+				t.Fatalf(`FilterInPlace %s: expected
+				%#v, got
+				%#v`, tc.name, tc.expected, res)
+			}
+			if len(res) > 0 && &res[0] != &tc.in[0] {
+				t.Fatalf("FilterInPlace %s: expected the result to share the backing array", tc.name)
+			}
+		})
+	}
+}
+
+func Test_FilterInPlace_ZeroesTail(t *testing.T) {
+	a, b, c := 1, 2, 3
+	in := []*int{&a, &b, &c}
+
+	res := FilterInPlace(in, func(p *int, _ int, _ []*int) bool { return *p == 2 })
+
+	expected := []*int{&b, nil, nil}
+	if len(res) != 1 || !reflect.DeepEqual(in, expected) {
+		t.Fatalf(`FilterInPlace: expected
+				%#v, got
+				%#v`, expected, in)
+	}
+}
+
+func Test_MapInPlace(t *testing.T) {
+	in := []int{1, 2, 3}
+	res := MapInPlace(in, func(v int, i int, _ []int) int { return v * 10 * (i + 1) })
+
+	expected := []int{10, 40, 90}
+	if !reflect.DeepEqual(res, expected) || !reflect.DeepEqual(in, expected) {
+		t.Fatalf(`MapInPlace: expected
+				%#v, got
+				%#v`, expected, res)
+	}
+
+	if res := MapInPlace(nil, func(v int, _ int, _ []int) int { return v }); res != nil {
+		t.Fatalf("MapInPlace: nil in - nil out, got %#v", res)
+	}
+}
+
+func Test_AppendMap(t *testing.T) {
+	res := AppendMap([]string{"0"}, []int{1, 2}, func(v int, _ int, _ []int) string { return strconv.Itoa(v) })
+
+	expected := []string{"0", "1", "2"}
+	if !reflect.DeepEqual(res, expected) {
+		t.Fatalf(`AppendMap: expected
+				%#v, got
+				%#v`, expected, res)
+	}
+
+	if res := AppendMap(nil, []int(nil), func(v int, _ int, _ []int) string { return "" }); res != nil {
+		t.Fatalf("AppendMap: nil in - nil out, got %#v", res)
+	}
+}
+
+func Test_AppendFilter(t *testing.T) {
+	res := AppendFilter([]int{0}, []int{1, 2, 3, 4}, func(v int, _ int, _ []int) bool { return v%2 == 0 })
+
+	expected := []int{0, 2, 4}
+	if !reflect.DeepEqual(res, expected) {
+		t.Fatalf(`AppendFilter: expected
+				%#v, got
+				%#v`, expected, res)
+	}
+
+	if res := AppendFilter(nil, []int{1}, func(v int, _ int, _ []int) bool { return false }); res != nil {
+		t.Fatalf("AppendFilter: nothing appended to nil - nil out, got %#v", res)
+	}
+}
+
+var allocsIn = Map(make([]int, 1000), func(_ int, i int, _ []int) int { return i })
+
+func Test_InPlace_Allocs(t *testing.T) {
+	buf := make([]int, len(allocsIn))
+	dst := make([]int, 0, len(allocsIn))
+
+	tt := []struct {
+		name string
+		run  func()
+	}{
+		{"FilterInPlace", func() {
+			copy(buf, allocsIn)
+			FilterInPlace(buf, func(v int, _ int, _ []int) bool { return v%2 == 0 })
+		}},
+		{"MapInPlace", func() {
+			MapInPlace(buf, func(v int, _ int, _ []int) int { return v + 1 })
+		}},
+		{"AppendMap", func() {
+			AppendMap(dst[:0], allocsIn, func(v int, _ int, _ []int) int { return v * 2 })
+		}},
+		{"AppendFilter", func() {
+			AppendFilter(dst[:0], allocsIn, func(v int, _ int, _ []int) bool { return v%2 == 0 })
+		}},
+	}
+
+	for _, tc := range tt {
+		if allocs := testing.AllocsPerRun(100, tc.run); allocs != 0 {
+			t.Fatalf("%s: expected no allocations, got %v", tc.name, allocs)
+		}
+	}
+}
+
+func Benchmark_Filter(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Filter(allocsIn, func(v int, _ int, _ []int) bool { return v%2 == 0 })
+	}
+}
+
+func Benchmark_FilterInPlace(b *testing.B) {
+	buf := make([]int, len(allocsIn))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(buf, allocsIn)
+		FilterInPlace(buf, func(v int, _ int, _ []int) bool { return v%2 == 0 })
+	}
+}
+
+func Benchmark_Map(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Map(allocsIn, func(v int, _ int, _ []int) int { return v * 2 })
+	}
+}
+
+func Benchmark_AppendMap(b *testing.B) {
+	dst := make([]int, 0, len(allocsIn))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dst = AppendMap(dst[:0], allocsIn, func(v int, _ int, _ []int) int { return v * 2 })
+	}
+}
@@ -0,0 +1,119 @@
+package slices
+
+// InnerJoin combines every pair of elements of two slices with equal keys.
+// Pairs go in the order of the left slice, then in the order of the right slice.
+// It is a hash join: the right slice is indexed by keys, so it takes O(len(left) + len(right) + len(result)).
+func InnerJoin[L, R, Y any, K comparable](left []L, right []R, leftKey func(L) K, rightKey func(R) K, combine func(L, R) Y) []Y {
+	if left == nil {
+		return nil
+	}
+
+	index := indexByKey(right, rightKey)
+	res := make([]Y, 0, len(left))
+	for _, l := range left {
+		for _, ri := range index[leftKey(l)] {
+			res = append(res, combine(l, right[ri]))
+		}
+	}
+
+	return res
+}
+
+// LeftJoin is like InnerJoin, but also keeps elements of the left slice which have no pair.
+// For them combine gets a zero value of R and matched set to false.
+func LeftJoin[L, R, Y any, K comparable](left []L, right []R, leftKey func(L) K, rightKey func(R) K, combine func(l L, r R, matched bool) Y) []Y {
+	if left == nil {
+		return nil
+	}
+
+	index := indexByKey(right, rightKey)
+	res := make([]Y, 0, len(left))
+	for _, l := range left {
+		matches := index[leftKey(l)]
+		if len(matches) == 0 {
+			var zero R
+			res = append(res, combine(l, zero, false))
+			continue
+		}
+		for _, ri := range matches {
+			res = append(res, combine(l, right[ri], true))
+		}
+	}
+
+	return res
+}
+
+// FullOuterJoin is like InnerJoin, but also keeps elements of both slices which have no pair.
+// For them combine gets a zero value on the missing side, and hasLeft or hasRight set to false.
+// Unmatched elements of the right slice go last, in the order of the right slice.
+func FullOuterJoin[L, R, Y any, K comparable](left []L, right []R, leftKey func(L) K, rightKey func(R) K, combine func(l L, r R, hasLeft, hasRight bool) Y) []Y {
+	if left == nil && right == nil {
+		return nil
+	}
+
+	index := indexByKey(right, rightKey)
+	matched := make([]bool, len(right))
+	res := make([]Y, 0, len(left)+len(right))
+	for _, l := range left {
+		matches := index[leftKey(l)]
+		if len(matches) == 0 {
+			var zero R
+			res = append(res, combine(l, zero, true, false))
+			continue
+		}
+		for _, ri := range matches {
+			matched[ri] = true
+			res = append(res, combine(l, right[ri], true, true))
+		}
+	}
+
+	var zero L
+	for ri, r := range right {
+		if !matched[ri] {
+			res = append(res, combine(zero, r, false, true))
+		}
+	}
+
+	return res
+}
+
+// SemiJoin returns elements of the left slice which have at least one element with the same key in the right slice.
+// Every element is returned once, no matter how many pairs it has.
+func SemiJoin[L, R any, K comparable](left []L, right []R, leftKey func(L) K, rightKey func(R) K) []L {
+	return filterByKeys(left, right, leftKey, rightKey, true)
+}
+
+// AntiJoin returns elements of the left slice which have no elements with the same key in the right slice.
+func AntiJoin[L, R any, K comparable](left []L, right []R, leftKey func(L) K, rightKey func(R) K) []L {
+	return filterByKeys(left, right, leftKey, rightKey, false)
+}
+
+func filterByKeys[L, R any, K comparable](left []L, right []R, leftKey func(L) K, rightKey func(R) K, keep bool) []L {
+	if left == nil {
+		return nil
+	}
+
+	keys := make(map[K]struct{}, len(right))
+	for _, r := range right {
+		keys[rightKey(r)] = struct{}{}
+	}
+
+	res := make([]L, 0, len(left))
+	for _, l := range left {
+		if _, ok := keys[leftKey(l)]; ok == keep {
+			res = append(res, l)
+		}
+	}
+
+	return res
+}
+
+// indexByKey maps keys to the positions of elements having them.
+func indexByKey[T any, K comparable](in []T, key func(T) K) map[K][]int {
+	index := make(map[K][]int, len(in))
+	for i, elem := range in {
+		k := key(elem)
+		index[k] = append(index[k], i)
+	}
+	return index
+}
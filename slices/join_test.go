@@ -0,0 +1,125 @@
+package slices
+
+import (
+	"reflect"
+	"testing"
+)
+
+type user struct {
+	ID   int
+	Name string
+}
+
+type order struct {
+	UserID int
+	Item   string
+}
+
+func userID(u user) int     { return u.ID }
+func orderUser(o order) int { return o.UserID }
+
+var (
+	joinUsers = []user{{1, "alice"}, {2, "bob"}, {3, "carol"}}
+	// orders of alice go in mixed order with others, dave (4) is unknown
+	joinOrders = []order{{1, "book"}, {3, "pen"}, {1, "lamp"}, {4, "cup"}}
+)
+
+func Test_InnerJoin(t *testing.T) {
+	res := InnerJoin(joinUsers, joinOrders, userID, orderUser, func(u user, o order) string {
+		return u.Name + ":" + o.Item
+	})
+
+	expected := []string{"alice:book", "alice:lamp", "carol:pen"}
+	if !reflect.DeepEqual(res, expected) {
+		t.Fatalf("InnerJoin: expected %#v, got %#v", expected, res)
+	}
+
+	if res := InnerJoin(nil, joinOrders, userID, orderUser, func(u user, o order) int { return 0 }); res != nil {
+		t.Fatalf("InnerJoin: nil in - nil out, got %#v", res)
+	}
+	if res := InnerJoin(joinUsers, nil, userID, orderUser, func(u user, o order) int { return 0 }); res == nil || len(res) != 0 {
+		t.Fatalf("InnerJoin: expected empty slice, got %#v", res)
+	}
+}
+
+func Test_LeftJoin(t *testing.T) {
+	res := LeftJoin(joinUsers, joinOrders, userID, orderUser, func(u user, o order, matched bool) string {
+		if !matched {
+			return u.Name + ":-"
+		}
+		return u.Name + ":" + o.Item
+	})
+
+	expected := []string{"alice:book", "alice:lamp", "bob:-", "carol:pen"}
+	if !reflect.DeepEqual(res, expected) {
+		t.Fatalf("LeftJoin: expected %#v, got %#v", expected, res)
+	}
+}
+
+func Test_FullOuterJoin(t *testing.T) {
+	combine := func(u user, o order, hasUser, hasOrder bool) string {
+		switch {
+		case !hasUser:
+			return "?:" + o.Item
+		case !hasOrder:
+			return u.Name + ":-"
+		default:
+			return u.Name + ":" + o.Item
+		}
+	}
+
+	tt := []struct {
+		name     string
+		users    []user
+		orders   []order
+		expected []string
+	}{
+		{
+			name:     "both sides",
+			users:    joinUsers,
+			orders:   joinOrders,
+			expected: []string{"alice:book", "alice:lamp", "bob:-", "carol:pen", "?:cup"},
+		},
+		{
+			name:     "only right",
+			users:    nil,
+			orders:   joinOrders[:2],
+			expected: []string{"?:book", "?:pen"},
+		},
+		{
+			name:     "nil in - nil out",
+			expected: nil,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			res := FullOuterJoin(tc.users, tc.orders, userID, orderUser, combine)
+
+			if !reflect.DeepEqual(res, tc.expected) {
+				t.Fatalf(`FullOuterJoin %s: expected
+				%#v, got
+				%#v`, tc.name, tc.expected, res)
+			}
+		})
+	}
+}
+
+func Test_SemiJoin_AntiJoin(t *testing.T) {
+	semi := SemiJoin(joinUsers, joinOrders, userID, orderUser)
+	if expected := []user{{1, "alice"}, {3, "carol"}}; !reflect.DeepEqual(semi, expected) {
+		t.Fatalf("SemiJoin: expected %#v, got %#v", expected, semi)
+	}
+
+	anti := AntiJoin(joinUsers, joinOrders, userID, orderUser)
+	if expected := []user{{2, "bob"}}; !reflect.DeepEqual(anti, expected) {
+		t.Fatalf("AntiJoin: expected %#v, got %#v", expected, anti)
+	}
+
+	if res := AntiJoin(joinUsers, nil, userID, orderUser); !reflect.DeepEqual(res, joinUsers) {
+		t.Fatalf("AntiJoin: expected all the elements, got %#v", res)
+	}
+	if SemiJoin(nil, joinOrders, userID, orderUser) != nil || AntiJoin(nil, joinOrders, userID, orderUser) != nil {
+		t.Fatal("SemiJoin, AntiJoin: nil in - nil out")
+	}
+}
@@ -0,0 +1,187 @@
+package slices
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// Functions of this file take a source of random numbers, so results can be reproduced in tests
+// with rand.New(rand.NewSource(seed)). With nil they use the global source of math/rand.
+
+func intn(r *rand.Rand, n int) int {
+	if r == nil {
+		return rand.Intn(n)
+	}
+	return r.Intn(n)
+}
+
+func float64n(r *rand.Rand) float64 {
+	if r == nil {
+		return rand.Float64()
+	}
+	return r.Float64()
+}
+
+// Shuffle returns a copy of a slice with elements in random order.
+func Shuffle[T any](in []T, r *rand.Rand) []T {
+	res := Copy(in)
+	ShuffleInPlace(res, r)
+
+	return res
+}
+
+// ShuffleInPlace puts elements of a slice in random order. Mutates original slice.
+func ShuffleInPlace[T any](in []T, r *rand.Rand) {
+	for i := len(in) - 1; i > 0; i-- {
+		j := intn(r, i+1)
+		in[i], in[j] = in[j], in[i]
+	}
+}
+
+// Sample returns n random elements of a slice without replacement, in random order.
+// If n is bigger than the length of the slice, all elements are returned.
+func Sample[T any](in []T, n int, r *rand.Rand) []T {
+	if in == nil {
+		return nil
+	}
+	if n > len(in) {
+		n = len(in)
+	}
+	if n < 0 {
+		n = 0
+	}
+
+	// a partial Fisher-Yates shuffle of indexes, which are swapped in a map not to copy the whole slice
+	swapped := make(map[int]int, n)
+	index := func(i int) int {
+		if j, ok := swapped[i]; ok {
+			return j
+		}
+		return i
+	}
+
+	res := make([]T, n)
+	for i := 0; i < n; i++ {
+		j := i + intn(r, len(in)-i)
+		res[i] = in[index(j)]
+		swapped[j] = index(i)
+	}
+
+	return res
+}
+
+// Reservoir keeps a uniform random sample of a fixed size of a stream of values of unknown length.
+// Every value added has an equal chance to be in the sample. It is not safe for concurrent use.
+type Reservoir[T any] struct {
+	size   int
+	seen   int
+	sample []T
+	r      *rand.Rand
+}
+
+// NewReservoir creates a reservoir keeping a sample of size values.
+func NewReservoir[T any](size int, r *rand.Rand) *Reservoir[T] {
+	if size < 0 {
+		size = 0
+	}
+	return &Reservoir[T]{size: size, sample: make([]T, 0, size), r: r}
+}
+
+// Add adds a value from the stream.
+func (s *Reservoir[T]) Add(v T) {
+	s.seen++
+	if len(s.sample) < s.size {
+		s.sample = append(s.sample, v)
+		return
+	}
+	if i := intn(s.r, s.seen); i < s.size {
+		s.sample[i] = v
+	}
+}
+
+// Seen returns the number of values added.
+func (s *Reservoir[T]) Seen() int {
+	return s.seen
+}
+
+// Sample returns a copy of the current sample, it has fewer values than the size until enough values are added.
+func (s *Reservoir[T]) Sample() []T {
+	return append([]T{}, s.sample...)
+}
+
+// WeightedChoice returns a random element of a slice, with chances proportional to weights given by a function.
+// Elements with zero, negative or infinite weights are never chosen. It returns false if there is nothing to choose from.
+func WeightedChoice[T any](in []T, weight func(T) float64, r *rand.Rand) (T, bool) {
+	total := 0.0
+	for _, elem := range in {
+		if w := weight(elem); w > 0 && !math.IsInf(w, 1) {
+			total += w
+		}
+	}
+
+	var zero T
+	if total <= 0 || math.IsInf(total, 1) {
+		return zero, false
+	}
+
+	target := float64n(r) * total
+	last := -1
+	for i, elem := range in {
+		w := weight(elem)
+		if !(w > 0) || math.IsInf(w, 1) {
+			continue
+		}
+		if target < w {
+			return elem, true
+		}
+		target -= w
+		last = i
+	}
+
+	// rounding errors can leave a tiny remainder after the last element
+	return in[last], true
+}
+
+// WeightedSample returns n random elements of a slice without replacement, with chances proportional
+// to weights given by a function, the heaviest elements being likely to go first.
+// Elements with zero, negative or infinite weights are never chosen, like in WeightedChoice,
+// so fewer than n elements can be returned.
+func WeightedSample[T any](in []T, n int, weight func(T) float64, r *rand.Rand) []T {
+	if in == nil {
+		return nil
+	}
+
+	// Efraimidis-Spirakis: every element gets a key u^(1/w), the largest keys win; logarithms keep the precision
+	type keyed struct {
+		key  float64
+		elem T
+	}
+	candidates := make([]keyed, 0, len(in))
+	for _, elem := range in {
+		w := weight(elem)
+		if !(w > 0) || math.IsInf(w, 1) {
+			continue
+		}
+		u := float64n(r)
+		for u == 0 {
+			u = float64n(r)
+		}
+		candidates = append(candidates, keyed{key: math.Log(u) / w, elem: elem})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].key > candidates[j].key })
+	if n < 0 {
+		n = 0
+	}
+	if n > len(candidates) {
+		n = len(candidates)
+	}
+
+	res := make([]T, n)
+	for i := range res {
+		res[i] = candidates[i].elem
+	}
+
+	return res
+}
@@ -0,0 +1,229 @@
+package slices
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func seeded() *rand.Rand {
+	return rand.New(rand.NewSource(42))
+}
+
+func sortedInts(in []int) []int {
+	res := Copy(in)
+	sort.Ints(res)
+	return res
+}
+
+func Test_Shuffle(t *testing.T) {
+	in := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+
+	res := Shuffle(in, seeded())
+	if !reflect.DeepEqual(sortedInts(res), in) {
+		t.Fatalf("Shuffle: expected a permutation of %#v, got %#v", in, res)
+	}
+	if !reflect.DeepEqual(in, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}) {
+		t.Fatalf("Shuffle: original slice is changed: %#v", in)
+	}
+	if again := Shuffle(in, seeded()); !reflect.DeepEqual(again, res) {
+		t.Fatalf(`Shuffle: expected the same order for the same seed
+				%#v, got
+				%#v`, res, again)
+	}
+	if reflect.DeepEqual(res, in) {
+		t.Fatalf("Shuffle: expected a different order, got %#v", res)
+	}
+
+	if res := Shuffle([]int(nil), seeded()); res != nil {
+		t.Fatalf("Shuffle: nil in - nil out, got %#v", res)
+	}
+	// the global source
+	if res := Shuffle(in, nil); !reflect.DeepEqual(sortedInts(res), in) {
+		t.Fatalf("Shuffle: expected a permutation of %#v, got %#v", in, res)
+	}
+}
+
+func Test_ShuffleInPlace_Uniform(t *testing.T) {
+	r := seeded()
+	counts := make(map[[3]int]int)
+	for i := 0; i < 6000; i++ {
+		in := []int{1, 2, 3}
+		ShuffleInPlace(in, r)
+		counts[[3]int{in[0], in[1], in[2]}]++
+	}
+
+	if len(counts) != 6 {
+		t.Fatalf("ShuffleInPlace: expected all 6 permutations, got %v", counts)
+	}
+	for p, c := range counts {
+		if c < 850 || c > 1150 {
+			t.Fatalf("ShuffleInPlace: permutation %v happened %d times of 6000", p, c)
+		}
+	}
+}
+
+func Test_Sample(t *testing.T) {
+	in := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+
+	tt := []struct {
+		name        string
+		n           int
+		expectedLen int
+	}{
+		{name: "some", n: 3, expectedLen: 3},
+		{name: "all", n: 9, expectedLen: 9},
+		{name: "more than there is", n: 20, expectedLen: 9},
+		{name: "none", n: 0, expectedLen: 0},
+		{name: "negative", n: -1, expectedLen: 0},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			res := Sample(in, tc.n, seeded())
+			if len(res) != tc.expectedLen {
+				t.Fatalf("Sample %s: expected %d elements, got %#v", tc.name, tc.expectedLen, res)
+			}
+			seen := make(map[int]bool)
+			for _, v := range res {
+				if seen[v] || v < 1 || v > 9 {
+					t.Fatalf("Sample %s: expected distinct elements of the slice, got %#v", tc.name, res)
+				}
+				seen[v] = true
+			}
+			if again := Sample(in, tc.n, seeded()); !reflect.DeepEqual(again, res) {
+				t.Fatalf("Sample %s: expected the same result for the same seed", tc.name)
+			}
+		})
+	}
+
+	if res := Sample([]int(nil), 3, seeded()); res != nil {
+		t.Fatalf("Sample: nil in - nil out, got %#v", res)
+	}
+}
+
+func Test_Sample_Uniform(t *testing.T) {
+	r := seeded()
+	counts := make([]int, 10)
+	for i := 0; i < 10000; i++ {
+		for _, v := range Sample([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, 3, r) {
+			counts[v]++
+		}
+	}
+
+	for v, c := range counts {
+		if c < 2700 || c > 3300 {
+			t.Fatalf("Sample: %d is chosen %d times of 10000, expected about 3000", v, c)
+		}
+	}
+}
+
+func Test_Reservoir(t *testing.T) {
+	r := seeded()
+	counts := make([]int, 10)
+	for i := 0; i < 10000; i++ {
+		s := NewReservoir[int](3, r)
+		if res := s.Sample(); len(res) != 0 {
+			t.Fatalf("Reservoir: expected an empty sample, got %#v", res)
+		}
+		for v := 0; v < 10; v++ {
+			s.Add(v)
+		}
+		if s.Seen() != 10 {
+			t.Fatalf("Reservoir: expected 10 values seen, got %d", s.Seen())
+		}
+		for _, v := range s.Sample() {
+			counts[v]++
+		}
+	}
+
+	for v, c := range counts {
+		if c < 2700 || c > 3300 {
+			t.Fatalf("Reservoir: %d is sampled %d times of 10000, expected about 3000", v, c)
+		}
+	}
+
+	s := NewReservoir[string](3, nil)
+	s.Add("a")
+	s.Add("b")
+	if res := s.Sample(); !reflect.DeepEqual(res, []string{"a", "b"}) {
+		t.Fatalf("Reservoir: expected all values while there are fewer than the size, got %#v", res)
+	}
+}
+
+type weighted struct {
+	name   string
+	weight float64
+}
+
+func weightOf(w weighted) float64 { return w.weight }
+
+func Test_WeightedChoice(t *testing.T) {
+	in := []weighted{{"a", 1}, {"zero", 0}, {"b", 3}, {"negative", -5}, {"nan", math.NaN()}}
+
+	r := seeded()
+	counts := make(map[string]int)
+	for i := 0; i < 8000; i++ {
+		res, ok := WeightedChoice(in, weightOf, r)
+		if !ok {
+			t.Fatalf("WeightedChoice: expected a choice")
+		}
+		counts[res.name]++
+	}
+
+	if len(counts) != 2 || counts["a"] < 1800 || counts["a"] > 2200 {
+		t.Fatalf("WeightedChoice: expected a about 2000 and b about 6000 times, got %v", counts)
+	}
+
+	tt := []struct {
+		name string
+		in   []weighted
+	}{
+		{name: "nil", in: nil},
+		{name: "no positive weights", in: []weighted{{"zero", 0}, {"negative", -1}}},
+	}
+	for _, tc := range tt {
+		if res, ok := WeightedChoice(tc.in, weightOf, seeded()); ok {
+			t.Fatalf("WeightedChoice %s: expected no choice, got %#v", tc.name, res)
+		}
+	}
+}
+
+func Test_WeightedSample(t *testing.T) {
+	in := []weighted{{"a", 1}, {"zero", 0}, {"b", 100}, {"c", 1}}
+
+	res := WeightedSample(in, 10, weightOf, seeded())
+	names := Map(res, func(w weighted, _ int, _ []weighted) string { return w.name })
+	sort.Strings(names)
+	if !reflect.DeepEqual(names, []string{"a", "b", "c"}) {
+		t.Fatalf("WeightedSample: expected all elements with positive weights, got %#v", names)
+	}
+
+	r := seeded()
+	first := 0
+	for i := 0; i < 1000; i++ {
+		res := WeightedSample(in, 1, weightOf, r)
+		if len(res) != 1 {
+			t.Fatalf("WeightedSample: expected 1 element, got %#v", res)
+		}
+		if res[0].name == "b" {
+			first++
+		}
+	}
+	if first < 960 {
+		t.Fatalf("WeightedSample: expected the heaviest element almost always, got it %d times of 1000", first)
+	}
+
+	if res := WeightedSample([]weighted(nil), 1, weightOf, seeded()); res != nil {
+		t.Fatalf("WeightedSample: nil in - nil out, got %#v", res)
+	}
+	withInf := append([]weighted{{"inf", math.Inf(1)}}, in...)
+	if res := WeightedSample(withInf, 10, weightOf, seeded()); len(res) != 3 || FindIndex(res, func(w weighted) bool { return w.name == "inf" }) != -1 {
+		t.Fatalf("WeightedSample: expected infinite weights to be skipped, got %#v", res)
+	}
+	if res := WeightedSample(in, -1, weightOf, seeded()); len(res) != 0 {
+		t.Fatalf("WeightedSample: expected nothing for negative n, got %#v", res)
+	}
+}
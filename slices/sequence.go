@@ -0,0 +1,105 @@
+package slices
+
+import (
+	"math"
+
+	"github.com/bullgare/funktional/constraints"
+)
+
+// Range returns numbers from start up to, but not including, end, with a given step.
+// A negative step counts down, from start down to, but not including, end (so unsigned numbers only go up).
+// The result is empty if the step is zero or goes away from end. Integers stop before they overflow,
+// floats are computed as start+i*step not to accumulate rounding errors. A float range is empty as well
+// if its length cannot be an int, like from -math.MaxFloat64 to math.MaxFloat64 (the length is infinite).
+func Range[T constraints.Number](start, end, step T) []T {
+	if !(step > 0 && start < end) && !(step < 0 && start > end) {
+		return []T{}
+	}
+
+	var half T = 1
+	if half /= 2; half != 0 {
+		// float64 keeps float32 ranges from overflowing, end-start can still be infinite
+		n := math.Ceil((float64(end) - float64(start)) / float64(step))
+		if math.IsInf(n, 0) || n >= math.MaxInt {
+			return []T{}
+		}
+		res := make([]T, int(n))
+		for i := range res {
+			res[i] = start + T(i)*step
+		}
+		// rounding can make the last elements reach end, like 1+3*0.1 in Range(1.0, 1.3, 0.1)
+		for len(res) > 0 && ((step > 0 && res[len(res)-1] >= end) || (step < 0 && res[len(res)-1] <= end)) {
+			res = res[:len(res)-1]
+		}
+		return res
+	}
+
+	var res []T
+	for v := start; (step > 0 && v < end) || (step < 0 && v > end); {
+		res = append(res, v)
+		next := v + step
+		if (next > v) != (step > 0) {
+			break
+		}
+		v = next
+	}
+
+	return res
+}
+
+// Repeat returns a slice of n copies of a value.
+func Repeat[T any](v T, n int) []T {
+	if n < 0 {
+		n = 0
+	}
+
+	res := make([]T, n)
+	for i := range res {
+		res[i] = v
+	}
+
+	return res
+}
+
+// Times returns a slice of n results of a function called with indexes from 0 to n-1.
+func Times[T any](n int, fn func(int) T) []T {
+	if n < 0 {
+		n = 0
+	}
+
+	res := make([]T, n)
+	for i := range res {
+		res[i] = fn(i)
+	}
+
+	return res
+}
+
+// Iterate returns a slice of n values: the seed, the function applied to it, the function applied to that, and so on.
+func Iterate[T any](seed T, fn func(T) T, n int) []T {
+	if n <= 0 {
+		return []T{}
+	}
+
+	res := make([]T, n)
+	res[0] = seed
+	for i := 1; i < n; i++ {
+		res[i] = fn(res[i-1])
+	}
+
+	return res
+}
+
+// Unfold builds a slice from a state: the function returns a value, the next state and true,
+// or false when there are no more values. It is the opposite of Reduce.
+func Unfold[T, S any](seed S, fn func(S) (T, S, bool)) []T {
+	res := []T{}
+	for state := seed; ; {
+		v, next, ok := fn(state)
+		if !ok {
+			return res
+		}
+		res = append(res, v)
+		state = next
+	}
+}
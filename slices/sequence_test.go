@@ -0,0 +1,122 @@
+package slices
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func Test_Range(t *testing.T) {
+	tt := []struct {
+		name     string
+		res      any
+		expected any
+	}{
+		{name: "up", res: Range(0, 5, 1), expected: []int{0, 1, 2, 3, 4}},
+		{name: "up with a step", res: Range(1, 10, 3), expected: []int{1, 4, 7}},
+		{name: "down", res: Range(5, 0, -2), expected: []int{5, 3, 1}},
+		{name: "empty", res: Range(3, 3, 1), expected: []int{}},
+		{name: "zero step", res: Range(0, 5, 0), expected: []int{}},
+		{name: "step away from end", res: Range(0, 5, -1), expected: []int{}},
+		{name: "unsigned", res: Range[uint8](250, 255, 2), expected: []uint8{250, 252, 254}},
+		{name: "unsigned down is empty", res: Range[uint](5, 0, 1), expected: []uint{}},
+		{name: "no overflow up", res: Range[int8](100, 127, 10), expected: []int8{100, 110, 120}},
+		{name: "no overflow down", res: Range[int8](-100, -128, -20), expected: []int8{-100, -120}},
+		{name: "no overflow at max", res: Range[uint8](0, 255, 100), expected: []uint8{0, 100, 200}},
+		{name: "max int", res: Range(math.MaxInt64-2, math.MaxInt64, 5), expected: []int{math.MaxInt64 - 2}},
+		{name: "floats", res: Range(0, 1, 0.25), expected: []float64{0, 0.25, 0.5, 0.75}},
+		{name: "floats without rounding errors", res: len(Range(0, 1, 0.1)), expected: 10},
+		{name: "floats rounded up to end", res: Range(1.0, 1.3, 0.1), expected: []float64{1, 1.1, 1.2}},
+		{name: "floats down", res: Range[float32](1, 0, -0.5), expected: []float32{1, 0.5}},
+		{name: "NaN step", res: Range(0, 1, math.NaN()), expected: []float64{}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tc.res, tc.expected) {
+				t.Fatalf(`Range %s: expected
+				%#v, got
+				%#v`, tc.name, tc.expected, tc.res)
+			}
+		})
+	}
+
+	if res := Range(0, 1, 0.1); res[9] != 0.9 {
+		t.Fatalf("Range: expected 0.9 as the last float, got %v", res[9])
+	}
+
+	overflows := []struct {
+		name             string
+		start, end, step float64
+	}{
+		{name: "infinite end", start: 0, end: math.Inf(1), step: 1},
+		{name: "infinite length", start: -math.MaxFloat64, end: math.MaxFloat64, step: 1},
+		{name: "infinite length down", start: math.MaxFloat64, end: -math.MaxFloat64, step: -1},
+		{name: "longer than int", start: 0, end: 1e300, step: 1},
+	}
+	for _, tc := range overflows {
+		if res := Range(tc.start, tc.end, tc.step); res == nil || len(res) != 0 {
+			t.Fatalf("Range %s: expected an empty slice, got %d elements", tc.name, len(res))
+		}
+	}
+
+	if res := Range[float32](-math.MaxFloat32, math.MaxFloat32, math.MaxFloat32); !reflect.DeepEqual(res, []float32{-math.MaxFloat32, 0}) {
+		t.Fatalf("Range: expected float32 bounds not to overflow, got %#v", res)
+	}
+}
+
+func Test_Repeat(t *testing.T) {
+	if res := Repeat("a", 3); !reflect.DeepEqual(res, []string{"a", "a", "a"}) {
+		t.Fatalf("Repeat: expected [a a a], got %#v", res)
+	}
+	for _, n := range []int{0, -1} {
+		if res := Repeat("a", n); res == nil || len(res) != 0 {
+			t.Fatalf("Repeat: expected an empty slice for %d, got %#v", n, res)
+		}
+	}
+}
+
+func Test_Times(t *testing.T) {
+	res := Times(3, func(i int) string { return "id" + strconv.Itoa(i) })
+	if !reflect.DeepEqual(res, []string{"id0", "id1", "id2"}) {
+		t.Fatalf("Times: expected [id0 id1 id2], got %#v", res)
+	}
+	if res := Times(-1, strconv.Itoa); res == nil || len(res) != 0 {
+		t.Fatalf("Times: expected an empty slice, got %#v", res)
+	}
+}
+
+func Test_Iterate(t *testing.T) {
+	res := Iterate(1, func(v int) int { return v * 2 }, 5)
+	if !reflect.DeepEqual(res, []int{1, 2, 4, 8, 16}) {
+		t.Fatalf("Iterate: expected [1 2 4 8 16], got %#v", res)
+	}
+
+	calls := 0
+	res = Iterate(1, func(v int) int { calls++; return v }, 1)
+	if !reflect.DeepEqual(res, []int{1}) || calls != 0 {
+		t.Fatalf("Iterate: expected only the seed without calls, got %#v and %d calls", res, calls)
+	}
+	if res := Iterate(1, func(v int) int { return v }, 0); res == nil || len(res) != 0 {
+		t.Fatalf("Iterate: expected an empty slice, got %#v", res)
+	}
+}
+
+func Test_Unfold(t *testing.T) {
+	// digits of a number, the lowest first
+	res := Unfold(1234, func(n int) (int, int, bool) {
+		if n == 0 {
+			return 0, 0, false
+		}
+		return n % 10, n / 10, true
+	})
+	if !reflect.DeepEqual(res, []int{4, 3, 2, 1}) {
+		t.Fatalf("Unfold: expected [4 3 2 1], got %#v", res)
+	}
+
+	res = Unfold(0, func(n int) (int, int, bool) { return 0, 0, false })
+	if res == nil || len(res) != 0 {
+		t.Fatalf("Unfold: expected an empty slice, got %#v", res)
+	}
+}
@@ -1,5 +1,7 @@
 package slices
 
+import "github.com/bullgare/funktional/deepcopy"
+
 // Map creates a slice by iterating over a given slice and applying a function to it.
 func Map[T, Y any](in []T, convert func(T, int, []T) Y) []Y {
 	if in == nil {
@@ -64,6 +66,12 @@ func Copy[T any](in []T) []T {
 	return out
 }
 
+// DeepCopy creates a deep copy of the given slice, following nested slices, maps, pointers and exported struct fields.
+// Types implementing deepcopy.Cloner are copied with their Clone method. See deepcopy.Copy for details.
+func DeepCopy[T any](in []T) []T {
+	return deepcopy.Copy(in)
+}
+
 // Chunk creates an array of elements splitted into groups the length of size.
 func Chunk[T any](in []T, size int) [][]T {
 	if in == nil {
@@ -166,3 +174,19 @@ func ReverseInPlace[T any](in []T) {
 		in[i], in[l-1-i] = in[l-1-i], in[i]
 	}
 }
+
+// EqualBy reports whether two slices have the same length and all their elements are equal by a given function.
+// Nil and empty slices are equal.
+func EqualBy[T any](a, b []T, eq func(T, T) bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if !eq(a[i], b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
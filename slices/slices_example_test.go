@@ -2,7 +2,9 @@ package slices
 
 import (
 	"fmt"
+	"math/rand"
 	"strconv"
+	"strings"
 )
 
 func ExampleMap() {
@@ -134,3 +136,230 @@ func ExampleReverseInPlace() {
 	// Output:
 	// []string{"d", "c", "b", "a"}
 }
+
+func ExampleDiff() {
+	a := []string{"a", "b", "c", "d"}
+	b := []string{"a", "c", "d", "e"}
+
+	for _, e := range Diff(a, b, func(x, y string) bool { return x == y }) {
+		fmt.Println(e.Op, e.Value)
+	}
+
+	// Output:
+	//   a
+	// - b
+	//   c
+	//   d
+	// + e
+}
+
+func ExampleLongestCommonSubsequence() {
+	res := LongestCommonSubsequence([]int{1, 2, 3, 4}, []int{2, 4, 5}, func(x, y int) bool { return x == y })
+	fmt.Printf("%#v\n", res)
+
+	// Output:
+	// []int{2, 4}
+}
+
+func ExampleApplyPatch() {
+	eq := func(x, y int) bool { return x == y }
+	script := Diff([]int{1, 2, 3}, []int{1, 3, 4}, eq)
+
+	res, err := ApplyPatch([]int{1, 2, 3}, script, eq)
+	fmt.Printf("%#v %v\n", res, err)
+
+	// Output:
+	// []int{1, 3, 4} <nil>
+}
+
+func ExampleDeepCopy() {
+	in := [][]int{{1, 2}, {3}}
+
+	out := DeepCopy(in)
+	in[0][0] = 10
+	fmt.Printf("%#v\n", out)
+
+	// Output:
+	// [][]int{[]int{1, 2}, []int{3}}
+}
+
+func ExampleEqualBy() {
+	a := []string{"Hello", "World"}
+	b := []string{"hello", "WORLD"}
+
+	fmt.Println(EqualBy(a, b, strings.EqualFold))
+
+	// Output:
+	// true
+}
+
+func ExampleLeftJoin() {
+	type user struct {
+		ID   int
+		Name string
+	}
+	type order struct {
+		UserID int
+		Total  float64
+	}
+
+	users := []user{{1, "alice"}, {2, "bob"}}
+	orders := []order{{1, 10}, {1, 5.5}}
+
+	res := LeftJoin(users, orders,
+		func(u user) int { return u.ID },
+		func(o order) int { return o.UserID },
+		func(u user, o order, matched bool) string {
+			if !matched {
+				return u.Name + " has no orders"
+			}
+			return fmt.Sprintf("%s paid %.2f", u.Name, o.Total)
+		},
+	)
+	fmt.Println(strings.Join(res, "\n"))
+
+	// Output:
+	// alice paid 10.00
+	// alice paid 5.50
+	// bob has no orders
+}
+
+func ExampleFilterInPlace() {
+	in := []int{1, 2, 3, 4, 5, 6}
+
+	even := FilterInPlace(in, func(v int, _ int, _ []int) bool { return v%2 == 0 })
+	fmt.Println(even)
+	// the tail of the original slice is zeroed
+	fmt.Println(in)
+
+	// Output:
+	// [2 4 6]
+	// [2 4 6 0 0 0]
+}
+
+func ExampleAppendMap() {
+	buf := make([]string, 0, 16)
+
+	for _, batch := range [][]int{{1, 2}, {3}} {
+		buf = AppendMap(buf[:0], batch, func(v int, _ int, _ []int) string { return strconv.Itoa(v * 10) })
+		fmt.Println(buf)
+	}
+
+	// Output:
+	// [10 20]
+	// [30]
+}
+
+func ExampleSample() {
+	// a seeded source makes the result reproducible, nil uses the global one
+	r := rand.New(rand.NewSource(1))
+
+	fmt.Println(Sample([]string{"a", "b", "c", "d", "e"}, 2, r))
+	fmt.Println(Shuffle([]int{1, 2, 3, 4, 5}, r))
+
+	// Output:
+	// [b e]
+	// [5 1 2 4 3]
+}
+
+func ExampleCartesianProduct() {
+	// a test matrix
+	for _, c := range CartesianProduct([]string{"linux", "darwin"}, []string{"amd64", "arm64"}) {
+		fmt.Println(c[0] + "/" + c[1])
+	}
+
+	// Output:
+	// linux/amd64
+	// linux/arm64
+	// darwin/amd64
+	// darwin/arm64
+}
+
+func ExampleForEachCombination() {
+	// finds two numbers with a given sum without keeping all pairs in memory
+	var found []int
+	ForEachCombination([]int{8, 3, 5, 9, 1}, 2, func(pair []int) bool {
+		if pair[0]+pair[1] == 10 {
+			found = Copy(pair)
+			return false
+		}
+		return true
+	})
+	fmt.Println(found)
+
+	// Output:
+	// [9 1]
+}
+
+func ExampleFlatMap() {
+	lines := []string{"to be", "or not", "to be"}
+
+	words := FlatMap(lines, func(line string, _ int, _ []string) []string { return strings.Fields(line) })
+	fmt.Println(len(words), words)
+
+	// Output:
+	// 6 [to be or not to be]
+}
+
+func ExampleUnfold() {
+	// the Collatz sequence
+	res := Unfold(6, func(n int) (int, int, bool) {
+		if n == 0 {
+			return 0, 0, false
+		}
+		next := n / 2
+		switch {
+		case n == 1:
+			next = 0
+		case n%2 == 1:
+			next = 3*n + 1
+		}
+		return n, next, true
+	})
+	fmt.Println(res)
+
+	// Output:
+	// [6 3 10 5 16 8 4 2 1]
+}
+
+func ExampleScan() {
+	balance := Scan([]int{100, -30, -50, 20}, func(acc int, change int, _ int) int { return acc + change }, 0)
+	fmt.Println(balance)
+
+	// Output:
+	// [100 70 20 40]
+}
+
+func ExampleReduceUntil() {
+	// takes items until the budget is exhausted, without looking at the rest
+	prices := []int{30, 50, 40, 10}
+	res := ReduceUntil(prices, func(acc []int, price int, _ int) ([]int, bool) {
+		if Reduce(acc, func(sum int, p int, _ int) int { return sum + p }, 0)+price > 100 {
+			return acc, true
+		}
+		return append(acc, price), false
+	}, nil)
+	fmt.Println(res)
+
+	// Output:
+	// [30 50]
+}
+
+func ExampleTakeView() {
+	queue := []string{"a", "b", "c", "d", "e"}
+
+	// processes the queue in batches without copying
+	for len(queue) > 0 {
+		fmt.Println(TakeView(queue, 2))
+		queue = DropView(queue, 2)
+	}
+
+	last, ok := Nth([]string{"a", "b", "c"}, -1)
+	fmt.Println(last, ok)
+
+	// Output:
+	// [a b]
+	// [c d]
+	// [e]
+	// c true
+}
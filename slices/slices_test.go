@@ -1,6 +1,7 @@
 package slices
 
 import (
+	"math"
 	"reflect"
 	"strconv"
 	"testing"
@@ -231,6 +232,33 @@ func Test_Copy_DeepCopyShouldNotWork(t *testing.T) {
 	}
 }
 
+func Test_DeepCopy(t *testing.T) {
+	type my struct {
+		A string
+	}
+
+	in := []*my{
+		{A: "a"},
+		{A: "b"},
+	}
+	res := DeepCopy(in)
+	in[0].A = "c"
+	expectedRes := []*my{
+		{A: "a"},
+		{A: "b"},
+	}
+
+	if !reflect.DeepEqual(res, expectedRes) {
+		t.Fatalf(`DeepCopy: expected
+				%#v, got
+				%#v`, expectedRes, res)
+	}
+
+	if res := DeepCopy[int](nil); res != nil {
+		t.Fatalf("DeepCopy: nil in - nil out, got %#v", res)
+	}
+}
+
 func Test_Chunk(t *testing.T) {
 	tt := []struct {
 		name     string
@@ -576,3 +604,44 @@ func Test_ReverseInPlace(t *testing.T) {
 		})
 	}
 }
+
+func Test_EqualBy(t *testing.T) {
+	tt := []struct {
+		name     string
+		a        []float64
+		b        []float64
+		expected bool
+	}{
+		{
+			name:     "equal within tolerance",
+			a:        []float64{1, 2.0001},
+			b:        []float64{1.0001, 2},
+			expected: true,
+		},
+		{
+			name:     "different element",
+			a:        []float64{1, 2},
+			b:        []float64{1, 3},
+			expected: false,
+		},
+		{
+			name:     "different length",
+			a:        []float64{1, 2},
+			b:        []float64{1},
+			expected: false,
+		},
+		{
+			name:     "nil and empty are equal",
+			a:        nil,
+			b:        []float64{},
+			expected: true,
+		},
+	}
+
+	for _, tc := range tt {
+		res := EqualBy(tc.a, tc.b, func(x, y float64) bool { return math.Abs(x-y) < 0.001 })
+		if res != tc.expected {
+			t.Fatalf("EqualBy %s: expected %v, got %v", tc.name, tc.expected, res)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package slices
+
+// Functions of this file never panic on out-of-range arguments. They return copies, like Copy does,
+// and their View variants return parts of the original slice sharing its backing array, which is cheaper,
+// but changes of elements are visible in both slices.
+
+// Take returns a copy of the first n elements of a slice, or of all of them if there are fewer.
+func Take[T any](in []T, n int) []T {
+	return Copy(TakeView(in, n))
+}
+
+// TakeView is like Take, but shares the backing array. Its capacity is limited,
+// so appending to it does not overwrite the rest of the original slice.
+func TakeView[T any](in []T, n int) []T {
+	n = clampIndex(n, len(in))
+	if in == nil {
+		return nil
+	}
+
+	return in[:n:n]
+}
+
+// Drop returns a copy of a slice without the first n elements, empty if there are fewer.
+func Drop[T any](in []T, n int) []T {
+	return Copy(DropView(in, n))
+}
+
+// DropView is like Drop, but shares the backing array.
+func DropView[T any](in []T, n int) []T {
+	n = clampIndex(n, len(in))
+	if in == nil {
+		return nil
+	}
+
+	return in[n:]
+}
+
+// TakeWhile returns a copy of the longest beginning of a slice which all elements satisfy the assertion.
+func TakeWhile[T any](in []T, assertion func(T, int) bool) []T {
+	return Copy(TakeWhileView(in, assertion))
+}
+
+// TakeWhileView is like TakeWhile, but shares the backing array. Its capacity is limited,
+// so appending to it does not overwrite the rest of the original slice.
+func TakeWhileView[T any](in []T, assertion func(T, int) bool) []T {
+	n := prefixLen(in, assertion)
+	if in == nil {
+		return nil
+	}
+
+	return in[:n:n]
+}
+
+// DropWhile returns a copy of a slice without its longest beginning which all elements satisfy the assertion.
+func DropWhile[T any](in []T, assertion func(T, int) bool) []T {
+	return Copy(DropWhileView(in, assertion))
+}
+
+// DropWhileView is like DropWhile, but shares the backing array.
+func DropWhileView[T any](in []T, assertion func(T, int) bool) []T {
+	n := prefixLen(in, assertion)
+	if in == nil {
+		return nil
+	}
+
+	return in[n:]
+}
+
+// Head returns the first element of a slice, or false if it is empty.
+func Head[T any](in []T) (T, bool) {
+	return Nth(in, 0)
+}
+
+// Tail returns a copy of a slice without the first element, empty if the slice is empty.
+func Tail[T any](in []T) []T {
+	return Drop(in, 1)
+}
+
+// TailView is like Tail, but shares the backing array.
+func TailView[T any](in []T) []T {
+	return DropView(in, 1)
+}
+
+// Last returns the last element of a slice, or false if it is empty.
+func Last[T any](in []T) (T, bool) {
+	return Nth(in, -1)
+}
+
+// Nth returns the element at a given index, or false if it is out of range.
+// A negative index counts from the end: -1 is the last element.
+func Nth[T any](in []T, i int) (T, bool) {
+	if i < 0 {
+		i += len(in)
+	}
+	if i < 0 || i >= len(in) {
+		var zero T
+		return zero, false
+	}
+
+	return in[i], true
+}
+
+// clampIndex limits an index to [0, length].
+func clampIndex(i, length int) int {
+	switch {
+	case i < 0:
+		return 0
+	case i > length:
+		return length
+	}
+	return i
+}
+
+// prefixLen returns the number of elements at the beginning of a slice which satisfy the assertion.
+func prefixLen[T any](in []T, assertion func(T, int) bool) int {
+	for i, elem := range in {
+		if !assertion(elem, i) {
+			return i
+		}
+	}
+	return len(in)
+}
@@ -0,0 +1,151 @@
+package slices
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_TakeDrop(t *testing.T) {
+	in := []int{1, 2, 3, 4, 5}
+
+	tt := []struct {
+		name         string
+		in           []int
+		n            int
+		expectedTake []int
+		expectedDrop []int
+	}{
+		{name: "some", in: in, n: 2, expectedTake: []int{1, 2}, expectedDrop: []int{3, 4, 5}},
+		{name: "all", in: in, n: 5, expectedTake: []int{1, 2, 3, 4, 5}, expectedDrop: []int{}},
+		{name: "more than there is", in: in, n: 10, expectedTake: []int{1, 2, 3, 4, 5}, expectedDrop: []int{}},
+		{name: "none", in: in, n: 0, expectedTake: []int{}, expectedDrop: []int{1, 2, 3, 4, 5}},
+		{name: "negative", in: in, n: -1, expectedTake: []int{}, expectedDrop: []int{1, 2, 3, 4, 5}},
+		{name: "empty", in: []int{}, n: 1, expectedTake: []int{}, expectedDrop: []int{}},
+		{name: "nil in - nil out", in: nil, n: 1, expectedTake: nil, expectedDrop: nil},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			results := map[string][2][]int{
+				"Take":     {Take(tc.in, tc.n), tc.expectedTake},
+				"TakeView": {TakeView(tc.in, tc.n), tc.expectedTake},
+				"Drop":     {Drop(tc.in, tc.n), tc.expectedDrop},
+				"DropView": {DropView(tc.in, tc.n), tc.expectedDrop},
+			}
+			for name, r := range results {
+				if !reflect.DeepEqual(r[0], r[1]) {
+					t.Fatalf(`%s %s: expected
+				%#v, got
+				%#v`, name, tc.name, r[1], r[0])
+				}
+			}
+		})
+	}
+}
+
+func Test_TakeWhileDropWhile(t *testing.T) {
+	less3 := func(v int, _ int) bool { return v < 3 }
+
+	tt := []struct {
+		name         string
+		in           []int
+		expectedTake []int
+		expectedDrop []int
+	}{
+		{name: "some", in: []int{1, 2, 3, 1}, expectedTake: []int{1, 2}, expectedDrop: []int{3, 1}},
+		{name: "all", in: []int{1, 2}, expectedTake: []int{1, 2}, expectedDrop: []int{}},
+		{name: "none", in: []int{3, 1}, expectedTake: []int{}, expectedDrop: []int{3, 1}},
+		{name: "nil in - nil out", in: nil, expectedTake: nil, expectedDrop: nil},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			results := map[string][2][]int{
+				"TakeWhile":     {TakeWhile(tc.in, less3), tc.expectedTake},
+				"TakeWhileView": {TakeWhileView(tc.in, less3), tc.expectedTake},
+				"DropWhile":     {DropWhile(tc.in, less3), tc.expectedDrop},
+				"DropWhileView": {DropWhileView(tc.in, less3), tc.expectedDrop},
+			}
+			for name, r := range results {
+				if !reflect.DeepEqual(r[0], r[1]) {
+					t.Fatalf(`%s %s: expected
+				%#v, got
+				%#v`, name, tc.name, r[1], r[0])
+				}
+			}
+		})
+	}
+}
+
+func Test_Views(t *testing.T) {
+	in := []int{1, 2, 3, 4}
+
+	Take(in, 2)[0] = 10
+	Drop(in, 2)[0] = 30
+	Tail(in)[0] = 20
+	if !reflect.DeepEqual(in, []int{1, 2, 3, 4}) {
+		t.Fatalf("copies: expected the original slice to stay untouched, got %#v", in)
+	}
+
+	TakeView(in, 2)[0] = 10
+	DropView(in, 2)[0] = 30
+	TailView(in)[0] = 20
+	if !reflect.DeepEqual(in, []int{10, 20, 30, 4}) {
+		t.Fatalf("views: expected changes to be visible in the original slice, got %#v", in)
+	}
+
+	_ = append(TakeView(in, 1), 0)
+	_ = append(TakeWhileView(in, func(v int, _ int) bool { return v < 15 }), 0)
+	if !reflect.DeepEqual(in, []int{10, 20, 30, 4}) {
+		t.Fatalf("views: expected appending not to overwrite the original slice, got %#v", in)
+	}
+}
+
+func Test_HeadTailLast(t *testing.T) {
+	in := []string{"a", "b", "c"}
+
+	if v, ok := Head(in); v != "a" || !ok {
+		t.Fatalf("Head: expected a, got %q, %v", v, ok)
+	}
+	if v, ok := Last(in); v != "c" || !ok {
+		t.Fatalf("Last: expected c, got %q, %v", v, ok)
+	}
+	if res := Tail(in); !reflect.DeepEqual(res, []string{"b", "c"}) {
+		t.Fatalf("Tail: expected [b c], got %#v", res)
+	}
+
+	for _, empty := range [][]string{nil, {}} {
+		if v, ok := Head(empty); v != "" || ok {
+			t.Fatalf("Head: expected nothing for %#v, got %q, %v", empty, v, ok)
+		}
+		if v, ok := Last(empty); v != "" || ok {
+			t.Fatalf("Last: expected nothing for %#v, got %q, %v", empty, v, ok)
+		}
+		if res := Tail(empty); !reflect.DeepEqual(res, empty) {
+			t.Fatalf("Tail: expected %#v, got %#v", empty, res)
+		}
+	}
+}
+
+func Test_Nth(t *testing.T) {
+	in := []string{"a", "b", "c"}
+
+	tt := []struct {
+		i          int
+		expected   string
+		expectedOk bool
+	}{
+		{i: 0, expected: "a", expectedOk: true},
+		{i: 2, expected: "c", expectedOk: true},
+		{i: 3, expected: "", expectedOk: false},
+		{i: -1, expected: "c", expectedOk: true},
+		{i: -3, expected: "a", expectedOk: true},
+		{i: -4, expected: "", expectedOk: false},
+	}
+
+	for _, tc := range tt {
+		if v, ok := Nth(in, tc.i); v != tc.expected || ok != tc.expectedOk {
+			t.Fatalf("Nth %d: expected %q, %v, got %q, %v", tc.i, tc.expected, tc.expectedOk, v, ok)
+		}
+	}
+}
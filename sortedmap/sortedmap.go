@@ -0,0 +1,350 @@
+// Package sortedmap implements a map with keys kept in order: an AVL tree with subtree sizes,
+// so lookups, range queries and rank queries are O(log n).
+package sortedmap
+
+import "github.com/bullgare/funktional/constraints"
+
+type node[K, V any] struct {
+	key         K
+	value       V
+	left, right *node[K, V]
+	height      int
+	size        int
+}
+
+// SortedMap is a map ordered by a less function. It is not safe for concurrent use.
+type SortedMap[K, V any] struct {
+	root *node[K, V]
+	less func(a, b K) bool
+}
+
+// New creates an empty map ordered by a less function.
+func New[K, V any](less func(a, b K) bool) *SortedMap[K, V] {
+	return &SortedMap[K, V]{less: less}
+}
+
+// NewOrdered creates an empty map of ordered keys in ascending order.
+func NewOrdered[K constraints.Ordered, V any]() *SortedMap[K, V] {
+	return New[K, V](func(a, b K) bool { return a < b })
+}
+
+// FromMap creates a sorted map of ordered keys from a map.
+func FromMap[K constraints.Ordered, V any](in map[K]V) *SortedMap[K, V] {
+	m := NewOrdered[K, V]()
+	for k, v := range in {
+		m.Put(k, v)
+	}
+	return m
+}
+
+// Len returns the number of keys.
+func (m *SortedMap[K, V]) Len() int {
+	return m.root.getSize()
+}
+
+// Get returns a value by its key and whether it was found.
+func (m *SortedMap[K, V]) Get(key K) (V, bool) {
+	n := m.root
+	for n != nil {
+		switch {
+		case m.less(key, n.key):
+			n = n.left
+		case m.less(n.key, key):
+			n = n.right
+		default:
+			return n.value, true
+		}
+	}
+
+	var zero V
+	return zero, false
+}
+
+// Put sets a value for a key. It returns true if the key was already in the map.
+func (m *SortedMap[K, V]) Put(key K, value V) bool {
+	var replaced bool
+	m.root = m.put(m.root, key, value, &replaced)
+	return replaced
+}
+
+// Delete removes a key. It returns false if there was no such key.
+func (m *SortedMap[K, V]) Delete(key K) bool {
+	var removed bool
+	m.root = m.delete(m.root, key, &removed)
+	return removed
+}
+
+// Min returns the smallest key. If the map is empty, ok is false.
+func (m *SortedMap[K, V]) Min() (key K, value V, ok bool) {
+	if m.root == nil {
+		return key, value, false
+	}
+	n := m.root.min()
+	return n.key, n.value, true
+}
+
+// Max returns the largest key. If the map is empty, ok is false.
+func (m *SortedMap[K, V]) Max() (key K, value V, ok bool) {
+	n := m.root
+	if n == nil {
+		return key, value, false
+	}
+	for n.right != nil {
+		n = n.right
+	}
+	return n.key, n.value, true
+}
+
+// Floor returns the largest key less than or equal to the given one. If there is no such key, ok is false.
+func (m *SortedMap[K, V]) Floor(key K) (K, V, bool) {
+	var found *node[K, V]
+	for n := m.root; n != nil; {
+		if m.less(key, n.key) {
+			n = n.left
+		} else {
+			found = n
+			n = n.right
+		}
+	}
+	return found.entry()
+}
+
+// Ceiling returns the smallest key greater than or equal to the given one. If there is no such key, ok is false.
+func (m *SortedMap[K, V]) Ceiling(key K) (K, V, bool) {
+	var found *node[K, V]
+	for n := m.root; n != nil; {
+		if m.less(n.key, key) {
+			n = n.right
+		} else {
+			found = n
+			n = n.left
+		}
+	}
+	return found.entry()
+}
+
+// Rank returns the number of keys less than the given one (so the index of the key, if it is in the map).
+func (m *SortedMap[K, V]) Rank(key K) int {
+	rank := 0
+	for n := m.root; n != nil; {
+		if m.less(n.key, key) {
+			rank += n.left.getSize() + 1
+			n = n.right
+		} else {
+			n = n.left
+		}
+	}
+	return rank
+}
+
+// Select returns the key with the index i in the order (starting with 0). If the index is out of range, ok is false.
+func (m *SortedMap[K, V]) Select(i int) (K, V, bool) {
+	if i < 0 || i >= m.Len() {
+		var (
+			key   K
+			value V
+		)
+		return key, value, false
+	}
+
+	n := m.root
+	for {
+		leftSize := n.left.getSize()
+		switch {
+		case i < leftSize:
+			n = n.left
+		case i > leftSize:
+			i -= leftSize + 1
+			n = n.right
+		default:
+			return n.key, n.value, true
+		}
+	}
+}
+
+// Range calls fn for keys from the given one (inclusive) up to another one (exclusive) in order, until fn returns false.
+func (m *SortedMap[K, V]) Range(from, to K, fn func(K, V) bool) {
+	m.rangeFrom(m.root, &from, &to, fn)
+}
+
+// Ascend calls fn for all the keys in ascending order, until fn returns false.
+func (m *SortedMap[K, V]) Ascend(fn func(K, V) bool) {
+	m.rangeFrom(m.root, nil, nil, fn)
+}
+
+// Descend calls fn for all the keys in descending order, until fn returns false.
+func (m *SortedMap[K, V]) Descend(fn func(K, V) bool) {
+	descend(m.root, fn)
+}
+
+// Keys returns all the keys in order.
+func (m *SortedMap[K, V]) Keys() []K {
+	out := make([]K, 0, m.Len())
+	m.Ascend(func(k K, _ V) bool {
+		out = append(out, k)
+		return true
+	})
+	return out
+}
+
+// Values returns all the values in the order of their keys.
+func (m *SortedMap[K, V]) Values() []V {
+	out := make([]V, 0, m.Len())
+	m.Ascend(func(_ K, v V) bool {
+		out = append(out, v)
+		return true
+	})
+	return out
+}
+
+// rangeFrom walks the keys in [from, to) in order, nil bounds are not checked. It returns false if fn asked to stop.
+func (m *SortedMap[K, V]) rangeFrom(n *node[K, V], from, to *K, fn func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+
+	afterFrom := from == nil || !m.less(n.key, *from)
+	beforeTo := to == nil || m.less(n.key, *to)
+	if afterFrom && !m.rangeFrom(n.left, from, to, fn) {
+		return false
+	}
+	if afterFrom && beforeTo && !fn(n.key, n.value) {
+		return false
+	}
+	if beforeTo {
+		return m.rangeFrom(n.right, from, to, fn)
+	}
+	return true
+}
+
+func descend[K, V any](n *node[K, V], fn func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	return descend(n.right, fn) && fn(n.key, n.value) && descend(n.left, fn)
+}
+
+func (m *SortedMap[K, V]) put(n *node[K, V], key K, value V, replaced *bool) *node[K, V] {
+	if n == nil {
+		return &node[K, V]{key: key, value: value, height: 1, size: 1}
+	}
+
+	switch {
+	case m.less(key, n.key):
+		n.left = m.put(n.left, key, value, replaced)
+	case m.less(n.key, key):
+		n.right = m.put(n.right, key, value, replaced)
+	default:
+		n.value = value
+		*replaced = true
+		return n
+	}
+	return n.balance()
+}
+
+func (m *SortedMap[K, V]) delete(n *node[K, V], key K, removed *bool) *node[K, V] {
+	if n == nil {
+		return nil
+	}
+
+	switch {
+	case m.less(key, n.key):
+		n.left = m.delete(n.left, key, removed)
+	case m.less(n.key, key):
+		n.right = m.delete(n.right, key, removed)
+	default:
+		*removed = true
+		if n.left == nil {
+			return n.right
+		}
+		if n.right == nil {
+			return n.left
+		}
+		successor := n.right.min()
+		successor.right = n.right.deleteMin()
+		successor.left = n.left
+		n = successor
+	}
+	return n.balance()
+}
+
+func (n *node[K, V]) entry() (key K, value V, ok bool) {
+	if n == nil {
+		return key, value, false
+	}
+	return n.key, n.value, true
+}
+
+func (n *node[K, V]) min() *node[K, V] {
+	for n.left != nil {
+		n = n.left
+	}
+	return n
+}
+
+func (n *node[K, V]) deleteMin() *node[K, V] {
+	if n.left == nil {
+		return n.right
+	}
+	n.left = n.left.deleteMin()
+	return n.balance()
+}
+
+func (n *node[K, V]) getHeight() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func (n *node[K, V]) getSize() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *node[K, V]) update() {
+	n.height = 1 + n.left.getHeight()
+	if h := n.right.getHeight(); h >= n.height {
+		n.height = h + 1
+	}
+	n.size = 1 + n.left.getSize() + n.right.getSize()
+}
+
+func (n *node[K, V]) rotateLeft() *node[K, V] {
+	r := n.right
+	n.right = r.left
+	r.left = n
+	n.update()
+	r.update()
+	return r
+}
+
+func (n *node[K, V]) rotateRight() *node[K, V] {
+	l := n.left
+	n.left = l.right
+	l.right = n
+	n.update()
+	l.update()
+	return l
+}
+
+// balance restores AVL invariant of the node after one of its subtrees was changed.
+func (n *node[K, V]) balance() *node[K, V] {
+	n.update()
+
+	switch diff := n.left.getHeight() - n.right.getHeight(); {
+	case diff > 1:
+		if n.left.left.getHeight() < n.left.right.getHeight() {
+			n.left = n.left.rotateLeft()
+		}
+		return n.rotateRight()
+	case diff < -1:
+		if n.right.right.getHeight() < n.right.left.getHeight() {
+			n.right = n.right.rotateRight()
+		}
+		return n.rotateLeft()
+	}
+	return n
+}
@@ -0,0 +1,27 @@
+package sortedmap
+
+import "fmt"
+
+func ExampleSortedMap() {
+	m := FromMap(map[int]string{10: "ten", 20: "twenty", 30: "thirty", 40: "forty"})
+
+	k, v, _ := m.Floor(25)
+	fmt.Println("floor of 25:", k, v)
+
+	k, v, _ = m.Ceiling(25)
+	fmt.Println("ceiling of 25:", k, v)
+
+	fmt.Println("rank of 30:", m.Rank(30))
+
+	m.Range(15, 40, func(k int, v string) bool {
+		fmt.Println(k, v)
+		return true
+	})
+
+	// Output:
+	// floor of 25: 20 twenty
+	// ceiling of 25: 30 thirty
+	// rank of 30: 2
+	// 20 twenty
+	// 30 thirty
+}
@@ -0,0 +1,216 @@
+package sortedmap
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/bullgare/funktional/maps"
+)
+
+// checkInvariants verifies AVL balance, sizes and order of all the nodes.
+func checkInvariants[K, V any](t *testing.T, m *SortedMap[K, V], n *node[K, V]) {
+	t.Helper()
+	if n == nil {
+		return
+	}
+	if d := n.left.getHeight() - n.right.getHeight(); d > 1 || d < -1 {
+		t.Fatalf("node %v is not balanced: %d", n.key, d)
+	}
+	if n.size != 1+n.left.getSize()+n.right.getSize() {
+		t.Fatalf("node %v has wrong size %d", n.key, n.size)
+	}
+	if n.left != nil && !m.less(n.left.key, n.key) || n.right != nil && !m.less(n.key, n.right.key) {
+		t.Fatalf("node %v is out of order", n.key)
+	}
+	checkInvariants(t, m, n.left)
+	checkInvariants(t, m, n.right)
+}
+
+func Test_SortedMap(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	m := NewOrdered[int, int]()
+	expected := map[int]int{}
+
+	for step := 0; step < 5000; step++ {
+		k := r.Intn(500)
+		if r.Intn(3) == 0 {
+			_, exists := expected[k]
+			if m.Delete(k) != exists {
+				t.Fatalf("Delete(%d): expected %v", k, exists)
+			}
+			delete(expected, k)
+		} else {
+			_, exists := expected[k]
+			if m.Put(k, step) != exists {
+				t.Fatalf("Put(%d): expected %v", k, exists)
+			}
+			expected[k] = step
+		}
+	}
+	checkInvariants(t, m, m.root)
+
+	keys := maps.Keys(expected)
+	sort.Ints(keys)
+
+	if m.Len() != len(keys) {
+		t.Fatalf("Len: expected %d, got %d", len(keys), m.Len())
+	}
+	if res := m.Keys(); !reflect.DeepEqual(res, keys) {
+		t.Fatalf("Keys: expected %v, got %v", keys, res)
+	}
+	for i, k := range keys {
+		if v, ok := m.Get(k); !ok || v != expected[k] {
+			t.Fatalf("Get(%d): expected %d, got %d", k, expected[k], v)
+		}
+		if rank := m.Rank(k); rank != i {
+			t.Fatalf("Rank(%d): expected %d, got %d", k, i, rank)
+		}
+		if sk, sv, ok := m.Select(i); !ok || sk != k || sv != expected[k] {
+			t.Fatalf("Select(%d): expected %d, got %d", i, k, sk)
+		}
+	}
+
+	for q := -1; q <= 501; q++ {
+		i := sort.SearchInts(keys, q)
+
+		ck, _, ok := m.Ceiling(q)
+		if ok != (i < len(keys)) || (ok && ck != keys[i]) {
+			t.Fatalf("Ceiling(%d): unexpected %d, %v", q, ck, ok)
+		}
+
+		fi := i
+		if i == len(keys) || keys[i] != q {
+			fi--
+		}
+		fk, _, ok := m.Floor(q)
+		if ok != (fi >= 0) || (ok && fk != keys[fi]) {
+			t.Fatalf("Floor(%d): unexpected %d, %v", q, fk, ok)
+		}
+	}
+}
+
+func Test_SortedMap_Range(t *testing.T) {
+	m := FromMap(map[string]int{"a": 1, "b": 2, "c": 3, "d": 4, "e": 5})
+
+	var res []string
+	m.Range("b", "e", func(k string, _ int) bool {
+		res = append(res, k)
+		return true
+	})
+	if expected := []string{"b", "c", "d"}; !reflect.DeepEqual(res, expected) {
+		t.Fatalf("Range: expected %v, got %v", expected, res)
+	}
+
+	res = nil
+	m.Range("bb", "z", func(k string, _ int) bool {
+		res = append(res, k)
+		return len(res) < 2
+	})
+	if expected := []string{"c", "d"}; !reflect.DeepEqual(res, expected) {
+		t.Fatalf("Range with stop: expected %v, got %v", expected, res)
+	}
+
+	res = nil
+	m.Descend(func(k string, _ int) bool {
+		res = append(res, k)
+		return k != "c"
+	})
+	if expected := []string{"e", "d", "c"}; !reflect.DeepEqual(res, expected) {
+		t.Fatalf("Descend: expected %v, got %v", expected, res)
+	}
+
+	if res := m.Values(); !reflect.DeepEqual(res, []int{1, 2, 3, 4, 5}) {
+		t.Fatalf("Values: unexpected %v", res)
+	}
+}
+
+func Test_SortedMap_Empty(t *testing.T) {
+	m := New[string, int](func(a, b string) bool { return a > b })
+
+	if _, _, ok := m.Min(); ok {
+		t.Fatal("Min: expected no keys")
+	}
+	if _, _, ok := m.Max(); ok {
+		t.Fatal("Max: expected no keys")
+	}
+	if _, _, ok := m.Select(0); ok {
+		t.Fatal("Select: expected no keys")
+	}
+	if m.Delete("a") {
+		t.Fatal("Delete: expected no keys")
+	}
+
+	m.Put("a", 1)
+	m.Put("b", 2)
+	if k, _, _ := m.Min(); k != "b" {
+		t.Fatalf("Min with reversed order: expected b, got %q", k)
+	}
+}
+
+const benchmarkSize = 10000
+
+func benchmarkData() map[int]int {
+	r := rand.New(rand.NewSource(1))
+	in := make(map[int]int, benchmarkSize)
+	for len(in) < benchmarkSize {
+		in[r.Int()] = len(in)
+	}
+	return in
+}
+
+func Benchmark_OrderedIteration_SortedMap(b *testing.B) {
+	m := FromMap(benchmarkData())
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Ascend(func(int, int) bool { return true })
+	}
+}
+
+func Benchmark_OrderedIteration_MapAndSort(b *testing.B) {
+	in := benchmarkData()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		keys := maps.Keys(in)
+		sort.Ints(keys)
+		for _, k := range keys {
+			_ = in[k]
+		}
+	}
+}
+
+func Benchmark_RangeQuery_SortedMap(b *testing.B) {
+	in := benchmarkData()
+	m := FromMap(in)
+	keys := m.Keys()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		from := keys[i%(benchmarkSize-10)]
+		m.Range(from, keys[i%(benchmarkSize-10)+10], func(int, int) bool { return true })
+	}
+}
+
+func Benchmark_RangeQuery_MapAndSort(b *testing.B) {
+	in := benchmarkData()
+	sorted := maps.Keys(in)
+	sort.Ints(sorted)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		from, to := sorted[i%(benchmarkSize-10)], sorted[i%(benchmarkSize-10)+10]
+		keys := maps.Keys(in)
+		sort.Ints(keys)
+		for _, k := range keys[sort.SearchInts(keys, from):sort.SearchInts(keys, to)] {
+			_ = in[k]
+		}
+	}
+}
+
+func Benchmark_Put(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		m := NewOrdered[int, int]()
+		for j := 0; j < benchmarkSize; j++ {
+			m.Put(j, j)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package table
+
+import (
+	"fmt"
+	"reflect"
+)
+
+type aggregationKind int
+
+const (
+	aggregateCount aggregationKind = iota
+	aggregateSum
+	aggregateMin
+	aggregateMax
+	aggregateMean
+)
+
+// Aggregation computes a value over the rows of a group. It is created with Count, Sum, Min, Max or Mean.
+type Aggregation struct {
+	kind   aggregationKind
+	column string
+	as     string
+}
+
+// Count counts rows of a group into an int column named as.
+func Count(as string) Aggregation {
+	return Aggregation{kind: aggregateCount, as: as}
+}
+
+// Sum adds up values of a numeric column into a column of the same type named as.
+// Missing values are skipped.
+func Sum(column, as string) Aggregation {
+	return Aggregation{kind: aggregateSum, column: column, as: as}
+}
+
+// Min finds the smallest value of a column. Missing values are skipped.
+func Min(column, as string) Aggregation {
+	return Aggregation{kind: aggregateMin, column: column, as: as}
+}
+
+// Max finds the largest value of a column. Missing values are skipped.
+func Max(column, as string) Aggregation {
+	return Aggregation{kind: aggregateMax, column: column, as: as}
+}
+
+// Mean computes the average of a numeric column into a float64 column named as.
+// Missing values are skipped, the result is missing if there are no values.
+func Mean(column, as string) Aggregation {
+	return Aggregation{kind: aggregateMean, column: column, as: as}
+}
+
+// Grouped is a table split into groups of rows by GroupBy.
+type Grouped struct {
+	t    *Table
+	keys []int
+	// groups hold row indexes, in the order of the first row of every group
+	groups [][]int
+}
+
+type groupNode struct {
+	children map[any]*groupNode
+	group    int
+}
+
+// GroupBy splits the rows into groups with equal values of given columns.
+// Groups go in the order of their first rows. Without columns all the rows form a single group.
+func (t *Table) GroupBy(columns ...string) (*Grouped, error) {
+	keys, err := t.columnIndexes(columns)
+	if err != nil {
+		return nil, err
+	}
+	for _, ci := range keys {
+		if !t.types[ci].Comparable() && t.types[ci].Kind() != reflect.Interface {
+			return nil, fmt.Errorf("%w: cannot group by %q of type %s", ErrColumnType, t.names[ci], t.types[ci])
+		}
+	}
+
+	g := &Grouped{t: t, keys: keys}
+	root := &groupNode{group: -1}
+	for ri := 0; ri < t.rows; ri++ {
+		node := root
+		for _, ci := range keys {
+			cell := t.cols[ci][ri]
+			if cell != nil && !reflect.TypeOf(cell).Comparable() {
+				return nil, fmt.Errorf("%w: cannot group by %q holding %T", ErrColumnType, t.names[ci], cell)
+			}
+			if node.children == nil {
+				node.children = make(map[any]*groupNode)
+			}
+			next, ok := node.children[cell]
+			if !ok {
+				next = &groupNode{group: -1}
+				node.children[cell] = next
+			}
+			node = next
+		}
+
+		if node.group == -1 {
+			node.group = len(g.groups)
+			g.groups = append(g.groups, nil)
+		}
+		g.groups[node.group] = append(g.groups[node.group], ri)
+	}
+
+	return g, nil
+}
+
+// Len returns the number of groups.
+func (g *Grouped) Len() int {
+	return len(g.groups)
+}
+
+// Aggregate creates a table with a row for every group: the columns it was grouped by go first, then aggregations.
+func (g *Grouped) Aggregate(aggregations ...Aggregation) (*Table, error) {
+	t := g.t
+	names := make([]string, 0, len(g.keys)+len(aggregations))
+	types := make([]reflect.Type, 0, len(g.keys)+len(aggregations))
+	for _, ci := range g.keys {
+		names = append(names, t.names[ci])
+		types = append(types, t.types[ci])
+	}
+
+	sources := make([]int, 0, len(aggregations))
+	for _, a := range aggregations {
+		ci, typ, err := g.aggregationType(a)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, a.as)
+		types = append(types, typ)
+		sources = append(sources, ci)
+	}
+
+	res, err := newTable(names, types, len(g.groups))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rows := range g.groups {
+		for k, ci := range g.keys {
+			res.cols[k] = append(res.cols[k], t.cols[ci][rows[0]])
+		}
+		for k, a := range aggregations {
+			var source []any
+			if sources[k] != -1 {
+				source = t.cols[sources[k]]
+			}
+			col := len(g.keys) + k
+			res.cols[col] = append(res.cols[col], aggregate(a.kind, source, types[col], rows))
+		}
+	}
+	res.rows = len(g.groups)
+
+	return res, nil
+}
+
+// aggregationType checks the source column of an aggregation and returns its index and the type of the result.
+func (g *Grouped) aggregationType(a Aggregation) (int, reflect.Type, error) {
+	if a.kind == aggregateCount {
+		return -1, reflect.TypeOf(0), nil
+	}
+
+	ci, ok := g.t.index[a.column]
+	if !ok {
+		return 0, nil, fmt.Errorf("%w: %q", ErrUnknownColumn, a.column)
+	}
+
+	typ := g.t.types[ci]
+	switch a.kind {
+	case aggregateSum, aggregateMean:
+		if !isNumber(typ.Kind()) {
+			return 0, nil, fmt.Errorf("%w: cannot aggregate %q of type %s, it should be a number", ErrColumnType, a.column, typ)
+		}
+		if a.kind == aggregateMean {
+			typ = reflect.TypeOf(float64(0))
+		}
+	default:
+		if !isOrdered(typ) {
+			return 0, nil, fmt.Errorf("%w: cannot find min or max of %q of type %s", ErrColumnType, a.column, typ)
+		}
+	}
+
+	return ci, typ, nil
+}
+
+func aggregate(kind aggregationKind, col []any, typ reflect.Type, rows []int) any {
+	switch kind {
+	case aggregateCount:
+		return len(rows)
+	case aggregateMin:
+		return best(col, rows, -1)
+	case aggregateMax:
+		return best(col, rows, 1)
+	case aggregateMean:
+		sum, count := sumFloat(col, rows)
+		if count == 0 {
+			return nil
+		}
+		return sum / float64(count)
+	}
+
+	switch k := typ.Kind(); {
+	case isInt(k):
+		var sum int64
+		for _, ri := range rows {
+			if col[ri] != nil {
+				sum += reflect.ValueOf(col[ri]).Int()
+			}
+		}
+		return reflect.ValueOf(sum).Convert(typ).Interface()
+	case isUint(k):
+		var sum uint64
+		for _, ri := range rows {
+			if col[ri] != nil {
+				sum += reflect.ValueOf(col[ri]).Uint()
+			}
+		}
+		return reflect.ValueOf(sum).Convert(typ).Interface()
+	default:
+		sum, _ := sumFloat(col, rows)
+		return reflect.ValueOf(sum).Convert(typ).Interface()
+	}
+}
+
+// best returns the smallest (sign -1) or the largest (sign 1) value skipping missing ones.
+func best(col []any, rows []int, sign int) any {
+	var res any
+	for _, ri := range rows {
+		if cell := col[ri]; cell != nil && (res == nil || compare(cell, res) == sign) {
+			res = cell
+		}
+	}
+	return res
+}
+
+func sumFloat(col []any, rows []int) (float64, int) {
+	sum, count := 0.0, 0
+	for _, ri := range rows {
+		if col[ri] != nil {
+			sum += toFloat(reflect.ValueOf(col[ri]))
+			count++
+		}
+	}
+	return sum, count
+}
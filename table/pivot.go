@@ -0,0 +1,122 @@
+package table
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Pivot turns values of the columns column into new columns (named by fmt.Sprint of the values).
+// Every distinct value of the index column becomes a row, and cells are taken from the values column.
+// Rows and new columns go in the order of their first appearance, cells without a value are missing (nil).
+// Other columns are dropped. ErrDuplicateCell is returned if several rows go to the same cell,
+// use GroupBy and Aggregate first to combine them.
+func (t *Table) Pivot(index, columns, values string) (*Table, error) {
+	idx, err := t.columnIndexes([]string{index, columns, values})
+	if err != nil {
+		return nil, err
+	}
+	ii, ci, vi := idx[0], idx[1], idx[2]
+
+	g, err := t.GroupBy(index)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{index}
+	types := []reflect.Type{t.types[ii]}
+	newColumns := make(map[string]int)
+	for ri := 0; ri < t.rows; ri++ {
+		name := fmt.Sprint(t.cols[ci][ri])
+		if _, ok := newColumns[name]; !ok {
+			newColumns[name] = len(names)
+			names = append(names, name)
+			types = append(types, t.types[vi])
+		}
+	}
+
+	res, err := newTable(names, types, g.Len())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rows := range g.groups {
+		row := make([]any, len(names))
+		row[0] = t.cols[ii][rows[0]]
+		filled := make([]bool, len(names))
+		for _, ri := range rows {
+			name := fmt.Sprint(t.cols[ci][ri])
+			col := newColumns[name]
+			if filled[col] {
+				return nil, fmt.Errorf("%w: %s=%v, %s=%s", ErrDuplicateCell, index, row[0], columns, name)
+			}
+			filled[col] = true
+			row[col] = t.cols[vi][ri]
+		}
+
+		for col, cell := range row {
+			res.cols[col] = append(res.cols[col], cell)
+		}
+	}
+	res.rows = g.Len()
+
+	return res, nil
+}
+
+// Unpivot is the opposite of Pivot: every column except the kept ones turns into a row,
+// with the column name in the names column and the cell in the values column.
+// The values column has the type of the unpivoted columns if it is the same for all of them, and any otherwise.
+func (t *Table) Unpivot(keep []string, names, values string) (*Table, error) {
+	keepIdx, err := t.columnIndexes(keep)
+	if err != nil {
+		return nil, err
+	}
+
+	kept := make(map[int]bool, len(keepIdx))
+	for _, ci := range keepIdx {
+		kept[ci] = true
+	}
+	var unpivoted []int
+	for ci := range t.names {
+		if !kept[ci] {
+			unpivoted = append(unpivoted, ci)
+		}
+	}
+
+	valuesType := reflect.TypeOf((*any)(nil)).Elem()
+	for k, ci := range unpivoted {
+		if k == 0 {
+			valuesType = t.types[ci]
+		} else if t.types[ci] != valuesType {
+			valuesType = reflect.TypeOf((*any)(nil)).Elem()
+			break
+		}
+	}
+
+	resNames := make([]string, 0, len(keepIdx)+2)
+	resTypes := make([]reflect.Type, 0, len(keepIdx)+2)
+	for _, ci := range keepIdx {
+		resNames = append(resNames, t.names[ci])
+		resTypes = append(resTypes, t.types[ci])
+	}
+	resNames = append(resNames, names, values)
+	resTypes = append(resTypes, reflect.TypeOf(""), valuesType)
+
+	res, err := newTable(resNames, resTypes, t.rows*len(unpivoted))
+	if err != nil {
+		return nil, err
+	}
+
+	nameCol, valueCol := len(keepIdx), len(keepIdx)+1
+	for ri := 0; ri < t.rows; ri++ {
+		for _, ci := range unpivoted {
+			for k, ki := range keepIdx {
+				res.cols[k] = append(res.cols[k], t.cols[ki][ri])
+			}
+			res.cols[nameCol] = append(res.cols[nameCol], t.names[ci])
+			res.cols[valueCol] = append(res.cols[valueCol], t.cols[ci][ri])
+		}
+	}
+	res.rows = t.rows * len(unpivoted)
+
+	return res, nil
+}
@@ -0,0 +1,366 @@
+// Package table implements a lightweight in-memory table with named typed columns
+// for reshaping slices of records: filtering, grouping with aggregates, pivoting and sorting.
+// Tables are immutable, every operation returns a new table.
+package table
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+var (
+	// ErrUnknownColumn is returned when a column with a given name is not in a table.
+	ErrUnknownColumn = errors.New("table: unknown column")
+	// ErrDuplicateColumn is returned when a table would have two columns with the same name.
+	ErrDuplicateColumn = errors.New("table: duplicate column")
+	// ErrColumnType is returned when a column has a type an operation does not support.
+	ErrColumnType = errors.New("table: unsupported column type")
+	// ErrDuplicateCell is returned by Pivot when several rows go to the same cell.
+	ErrDuplicateCell = errors.New("table: duplicate cell")
+)
+
+// Table is a set of named columns of the same length. Cells are stored as values of the column type,
+// and nil stands for a missing value (Pivot produces them).
+type Table struct {
+	names []string
+	types []reflect.Type
+	cols  [][]any
+	index map[string]int
+	rows  int
+}
+
+// Column defines how to get a value of a column from a record. It is created with Col.
+type Column[T any] struct {
+	name string
+	typ  reflect.Type
+	get  func(T) any
+}
+
+// Col defines a column of type V named name with values returned by get.
+func Col[T, V any](name string, get func(T) V) Column[T] {
+	return Column[T]{
+		name: name,
+		typ:  reflect.TypeOf((*V)(nil)).Elem(),
+		get:  func(item T) any { return get(item) },
+	}
+}
+
+// FromSlice creates a table from records, with a column for every definition.
+func FromSlice[T any](in []T, columns ...Column[T]) (*Table, error) {
+	names := make([]string, 0, len(columns))
+	types := make([]reflect.Type, 0, len(columns))
+	for _, c := range columns {
+		names = append(names, c.name)
+		types = append(types, c.typ)
+	}
+
+	t, err := newTable(names, types, len(in))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range in {
+		for ci, c := range columns {
+			t.cols[ci] = append(t.cols[ci], c.get(item))
+		}
+	}
+	t.rows = len(in)
+
+	return t, nil
+}
+
+func newTable(names []string, types []reflect.Type, capacity int) (*Table, error) {
+	t := &Table{
+		names: names,
+		types: types,
+		cols:  make([][]any, len(names)),
+		index: make(map[string]int, len(names)),
+	}
+
+	for i, name := range names {
+		if _, ok := t.index[name]; ok {
+			return nil, fmt.Errorf("%w: %q", ErrDuplicateColumn, name)
+		}
+		t.index[name] = i
+		t.cols[i] = make([]any, 0, capacity)
+	}
+
+	return t, nil
+}
+
+// columnIndexes returns positions of columns with given names.
+func (t *Table) columnIndexes(names []string) ([]int, error) {
+	res := make([]int, 0, len(names))
+	for _, name := range names {
+		i, ok := t.index[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownColumn, name)
+		}
+		res = append(res, i)
+	}
+	return res, nil
+}
+
+// pick creates a table with the same columns and given rows of the original table.
+func (t *Table) pick(rows []int) *Table {
+	res := &Table{names: t.names, types: t.types, cols: make([][]any, len(t.cols)), index: t.index, rows: len(rows)}
+	for ci, col := range t.cols {
+		res.cols[ci] = make([]any, 0, len(rows))
+		for _, ri := range rows {
+			res.cols[ci] = append(res.cols[ci], col[ri])
+		}
+	}
+	return res
+}
+
+// Len returns the number of rows.
+func (t *Table) Len() int {
+	return t.rows
+}
+
+// Columns returns the names of the columns in their order.
+func (t *Table) Columns() []string {
+	return append([]string(nil), t.names...)
+}
+
+// ColumnType returns the type of a column.
+func (t *Table) ColumnType(name string) (reflect.Type, bool) {
+	i, ok := t.index[name]
+	if !ok {
+		return nil, false
+	}
+	return t.types[i], true
+}
+
+// Row returns a row by its index. It panics if the index is out of range.
+func (t *Table) Row(i int) Row {
+	if i < 0 || i >= t.rows {
+		panic(fmt.Sprintf("table: row index %d out of range [0:%d]", i, t.rows))
+	}
+	return Row{t: t, i: i}
+}
+
+// Select creates a table with given columns only, in the given order.
+func (t *Table) Select(columns ...string) (*Table, error) {
+	idx, err := t.columnIndexes(columns)
+	if err != nil {
+		return nil, err
+	}
+
+	types := make([]reflect.Type, 0, len(idx))
+	for _, i := range idx {
+		types = append(types, t.types[i])
+	}
+	res, err := newTable(append([]string(nil), columns...), types, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	for ri, i := range idx {
+		// columns are never changed after creation, so they can be shared
+		res.cols[ri] = t.cols[i]
+	}
+	res.rows = t.rows
+
+	return res, nil
+}
+
+// Where creates a table with the rows a given function returns true for.
+func (t *Table) Where(filter func(Row) bool) *Table {
+	rows := make([]int, 0, t.rows)
+	for i := 0; i < t.rows; i++ {
+		if filter(Row{t: t, i: i}) {
+			rows = append(rows, i)
+		}
+	}
+	return t.pick(rows)
+}
+
+// SortBy creates a table with rows sorted by given columns, the first one being the most significant.
+// A column name prefixed with "-" sorts in descending order. The sort is stable.
+// Columns should hold numbers, strings or bools, missing values go first.
+func (t *Table) SortBy(columns ...string) (*Table, error) {
+	idx := make([]int, 0, len(columns))
+	desc := make([]bool, 0, len(columns))
+	for _, name := range columns {
+		d := strings.HasPrefix(name, "-")
+		i, ok := t.index[strings.TrimPrefix(name, "-")]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownColumn, name)
+		}
+		if !isOrdered(t.types[i]) {
+			return nil, fmt.Errorf("%w: cannot sort by %q of type %s", ErrColumnType, name, t.types[i])
+		}
+		idx = append(idx, i)
+		desc = append(desc, d)
+	}
+
+	rows := make([]int, t.rows)
+	for i := range rows {
+		rows[i] = i
+	}
+	sort.SliceStable(rows, func(a, b int) bool {
+		for k, ci := range idx {
+			c := compare(t.cols[ci][rows[a]], t.cols[ci][rows[b]])
+			if c == 0 {
+				continue
+			}
+			return (c < 0) != desc[k]
+		}
+		return false
+	})
+
+	return t.pick(rows), nil
+}
+
+// Rows exports the table as a slice of rows keyed by column names. Missing values are nil.
+func (t *Table) Rows() []map[string]any {
+	res := make([]map[string]any, 0, t.rows)
+	for i := 0; i < t.rows; i++ {
+		res = append(res, Row{t: t, i: i}.Map())
+	}
+	return res
+}
+
+// String renders the table as tab separated values with a header.
+func (t *Table) String() string {
+	var sb strings.Builder
+	sb.WriteString(strings.Join(t.names, "\t"))
+	for i := 0; i < t.rows; i++ {
+		sb.WriteByte('\n')
+		for ci, col := range t.cols {
+			if ci > 0 {
+				sb.WriteByte('\t')
+			}
+			if col[i] != nil {
+				fmt.Fprint(&sb, col[i])
+			}
+		}
+	}
+	return sb.String()
+}
+
+// Row is a row of a table.
+type Row struct {
+	t *Table
+	i int
+}
+
+// Index returns the index of the row in its table.
+func (r Row) Index() int {
+	return r.i
+}
+
+// Get returns a cell of a given column. It returns nil for an unknown column or a missing value.
+func (r Row) Get(column string) any {
+	ci, ok := r.t.index[column]
+	if !ok {
+		return nil
+	}
+	return r.t.cols[ci][r.i]
+}
+
+// Map returns the row keyed by column names.
+func (r Row) Map() map[string]any {
+	res := make(map[string]any, len(r.t.names))
+	for ci, name := range r.t.names {
+		res[name] = r.t.cols[ci][r.i]
+	}
+	return res
+}
+
+// Value returns a cell of a given column as V.
+// It returns false for an unknown column, a missing value or a value of another type.
+func Value[V any](r Row, column string) (V, bool) {
+	v, ok := r.Get(column).(V)
+	return v, ok
+}
+
+// Values returns all the cells of a column as V. Missing values are zero values.
+func Values[V any](t *Table, column string) ([]V, error) {
+	ci, ok := t.index[column]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+	}
+
+	res := make([]V, 0, t.rows)
+	for _, cell := range t.cols[ci] {
+		if cell == nil {
+			var zero V
+			res = append(res, zero)
+			continue
+		}
+		v, ok := cell.(V)
+		if !ok {
+			return nil, fmt.Errorf("%w: %q holds %T", ErrColumnType, column, cell)
+		}
+		res = append(res, v)
+	}
+
+	return res, nil
+}
+
+// ToSlice exports the table into a slice of structs.
+// A column goes to an exported field with the same name or with a `table:"name"` tag, other columns are skipped.
+// Numbers are converted between numeric types, missing values leave fields with zero values.
+func ToSlice[T any](t *Table) ([]T, error) {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: cannot export into %s, it should be a struct", ErrColumnType, typ)
+	}
+
+	// fields[ci] is a field index for column ci or -1
+	fields := make([]int, len(t.names))
+	for ci := range fields {
+		fields[ci] = -1
+	}
+	for fi := 0; fi < typ.NumField(); fi++ {
+		f := typ.Field(fi)
+		if !f.IsExported() {
+			continue
+		}
+		name := f.Name
+		if tag, ok := f.Tag.Lookup("table"); ok {
+			if tag == "-" {
+				continue
+			}
+			name = tag
+		}
+		if ci, ok := t.index[name]; ok {
+			fields[ci] = fi
+		}
+	}
+
+	res := make([]T, t.rows)
+	for ci, fi := range fields {
+		if fi == -1 {
+			continue
+		}
+		for ri, cell := range t.cols[ci] {
+			if cell == nil {
+				continue
+			}
+			field := reflect.ValueOf(&res[ri]).Elem().Field(fi)
+			if err := assign(field, reflect.ValueOf(cell)); err != nil {
+				return nil, fmt.Errorf("column %q: %w", t.names[ci], err)
+			}
+		}
+	}
+
+	return res, nil
+}
+
+func assign(dst, src reflect.Value) error {
+	switch {
+	case src.Type().AssignableTo(dst.Type()):
+		dst.Set(src)
+	case isNumber(src.Kind()) && isNumber(dst.Kind()):
+		dst.Set(src.Convert(dst.Type()))
+	default:
+		return fmt.Errorf("%w: cannot assign %s to %s", ErrColumnType, src.Type(), dst.Type())
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package table
+
+import "fmt"
+
+type order struct {
+	Month    string
+	Category string
+	Amount   float64
+}
+
+func ExampleTable_Pivot() {
+	orders := []order{
+		{"jan", "books", 20},
+		{"jan", "games", 60},
+		{"feb", "books", 15},
+		{"jan", "books", 5},
+	}
+
+	t, _ := FromSlice(orders,
+		Col("month", func(o order) string { return o.Month }),
+		Col("category", func(o order) string { return o.Category }),
+		Col("amount", func(o order) float64 { return o.Amount }),
+	)
+
+	grouped, _ := t.GroupBy("month", "category")
+	totals, _ := grouped.Aggregate(Sum("amount", "total"))
+	report, _ := totals.Pivot("month", "category", "total")
+
+	fmt.Println(report)
+
+	// Output:
+	// month	books	games
+	// jan	25	60
+	// feb	15
+}
+
+func ExampleToSlice() {
+	type summary struct {
+		Category string  `table:"category"`
+		Orders   int     `table:"orders"`
+		Average  float64 `table:"average"`
+	}
+
+	orders := []order{
+		{"jan", "books", 20},
+		{"jan", "games", 60},
+		{"feb", "books", 10},
+	}
+
+	t, _ := FromSlice(orders,
+		Col("category", func(o order) string { return o.Category }),
+		Col("amount", func(o order) float64 { return o.Amount }),
+	)
+
+	grouped, _ := t.GroupBy("category")
+	stats, _ := grouped.Aggregate(Count("orders"), Mean("amount", "average"))
+	sorted, _ := stats.SortBy("-average")
+	res, _ := ToSlice[summary](sorted)
+
+	fmt.Printf("%+v\n", res)
+
+	// Output:
+	// [{Category:games Orders:1 Average:60} {Category:books Orders:2 Average:15}]
+}
@@ -0,0 +1,250 @@
+package table
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type sale struct {
+	Region  string
+	Product string
+	Units   int
+	Price   float64
+}
+
+var sales = []sale{
+	{"north", "apple", 10, 1.5},
+	{"south", "apple", 4, 1.2},
+	{"north", "pear", 3, 2},
+	{"south", "plum", 7, 3},
+	{"north", "apple", 5, 1.7},
+}
+
+func salesTable(t *testing.T) *Table {
+	t.Helper()
+	tbl, err := FromSlice(sales,
+		Col("region", func(s sale) string { return s.Region }),
+		Col("product", func(s sale) string { return s.Product }),
+		Col("units", func(s sale) int { return s.Units }),
+		Col("price", func(s sale) float64 { return s.Price }),
+	)
+	if err != nil {
+		t.Fatalf("FromSlice: unexpected error %v", err)
+	}
+	return tbl
+}
+
+func Test_FromSlice(t *testing.T) {
+	tbl := salesTable(t)
+
+	if tbl.Len() != 5 || !reflect.DeepEqual(tbl.Columns(), []string{"region", "product", "units", "price"}) {
+		t.Fatalf("FromSlice: unexpected table\n%s", tbl)
+	}
+	if typ, ok := tbl.ColumnType("units"); !ok || typ != reflect.TypeOf(0) {
+		t.Fatalf("ColumnType: unexpected %v", typ)
+	}
+	if v, ok := Value[float64](tbl.Row(2), "price"); !ok || v != 2 {
+		t.Fatalf("Value: unexpected %v", v)
+	}
+	if _, ok := Value[string](tbl.Row(2), "price"); ok {
+		t.Fatal("Value: expected type mismatch")
+	}
+
+	_, err := FromSlice(sales, Col("a", func(s sale) int { return 0 }), Col("a", func(s sale) int { return 0 }))
+	if !errors.Is(err, ErrDuplicateColumn) {
+		t.Fatalf("FromSlice: expected ErrDuplicateColumn, got %v", err)
+	}
+
+	empty, err := FromSlice[sale](nil, Col("a", func(s sale) int { return 0 }))
+	if err != nil || empty.Len() != 0 || len(empty.Rows()) != 0 {
+		t.Fatalf("FromSlice: expected an empty table, got %v, %v", empty, err)
+	}
+}
+
+func Test_Select_Where(t *testing.T) {
+	tbl := salesTable(t)
+
+	res, err := tbl.Select("units", "product")
+	if err != nil {
+		t.Fatalf("Select: unexpected error %v", err)
+	}
+	res = res.Where(func(r Row) bool {
+		units, _ := Value[int](r, "units")
+		return units >= 5
+	})
+
+	expected := []map[string]any{
+		{"units": 10, "product": "apple"},
+		{"units": 7, "product": "plum"},
+		{"units": 5, "product": "apple"},
+	}
+	if !reflect.DeepEqual(res.Rows(), expected) {
+		t.Fatalf("Select, Where: expected %v, got %v", expected, res.Rows())
+	}
+	if tbl.Len() != 5 {
+		t.Fatal("Where: original table was changed")
+	}
+
+	if _, err := tbl.Select("units", "nope"); !errors.Is(err, ErrUnknownColumn) {
+		t.Fatalf("Select: expected ErrUnknownColumn, got %v", err)
+	}
+	if _, err := tbl.Select("units", "units"); !errors.Is(err, ErrDuplicateColumn) {
+		t.Fatalf("Select: expected ErrDuplicateColumn, got %v", err)
+	}
+}
+
+func Test_SortBy(t *testing.T) {
+	tbl := salesTable(t)
+
+	tt := []struct {
+		name     string
+		columns  []string
+		expected []int
+		err      error
+	}{
+		{name: "one column", columns: []string{"units"}, expected: []int{3, 4, 5, 7, 10}},
+		{name: "stable and descending", columns: []string{"-region"}, expected: []int{4, 7, 10, 3, 5}},
+		{name: "several columns", columns: []string{"product", "-price"}, expected: []int{5, 10, 4, 3, 7}},
+		{name: "unknown column", columns: []string{"-nope"}, err: ErrUnknownColumn},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := tbl.SortBy(tc.columns...)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("SortBy %s: expected error %v, got %v", tc.name, tc.err, err)
+			}
+			if err != nil {
+				return
+			}
+
+			units, _ := Values[int](res, "units")
+			if !reflect.DeepEqual(units, tc.expected) {
+				t.Fatalf("SortBy %s: expected %v, got %v", tc.name, tc.expected, units)
+			}
+		})
+	}
+
+	type withSlice struct{ S []int }
+	bad, _ := FromSlice([]withSlice{{}}, Col("s", func(w withSlice) []int { return w.S }))
+	if _, err := bad.SortBy("s"); !errors.Is(err, ErrColumnType) {
+		t.Fatalf("SortBy: expected ErrColumnType, got %v", err)
+	}
+}
+
+func Test_GroupBy_Aggregate(t *testing.T) {
+	tbl := salesTable(t)
+
+	g, err := tbl.GroupBy("region", "product")
+	if err != nil {
+		t.Fatalf("GroupBy: unexpected error %v", err)
+	}
+	res, err := g.Aggregate(Count("n"), Sum("units", "units"), Min("price", "min"), Max("price", "max"), Mean("price", "mean"))
+	if err != nil {
+		t.Fatalf("Aggregate: unexpected error %v", err)
+	}
+
+	expected := `region	product	n	units	min	max	mean
+north	apple	2	15	1.5	1.7	1.6
+south	apple	1	4	1.2	1.2	1.2
+north	pear	1	3	2	2	2
+south	plum	1	7	3	3	3`
+	if res.String() != expected {
+		t.Fatalf("Aggregate: expected\n%s\ngot\n%s", expected, res)
+	}
+	if typ, _ := res.ColumnType("units"); typ != reflect.TypeOf(0) {
+		t.Fatalf("Aggregate: Sum should keep the column type, got %v", typ)
+	}
+
+	total, err := tbl.GroupBy()
+	if err != nil {
+		t.Fatalf("GroupBy: unexpected error %v", err)
+	}
+	totalRes, _ := total.Aggregate(Sum("units", "units"), Sum("price", "price"))
+	if !reflect.DeepEqual(totalRes.Rows(), []map[string]any{{"units": 29, "price": 9.4}}) {
+		t.Fatalf("Aggregate: unexpected total %v", totalRes.Rows())
+	}
+
+	if _, err := g.Aggregate(Sum("product", "x")); !errors.Is(err, ErrColumnType) {
+		t.Fatalf("Aggregate: expected ErrColumnType, got %v", err)
+	}
+	if _, err := g.Aggregate(Max("nope", "x")); !errors.Is(err, ErrUnknownColumn) {
+		t.Fatalf("Aggregate: expected ErrUnknownColumn, got %v", err)
+	}
+	if _, err := g.Aggregate(Count("region")); !errors.Is(err, ErrDuplicateColumn) {
+		t.Fatalf("Aggregate: expected ErrDuplicateColumn, got %v", err)
+	}
+}
+
+func Test_Pivot_Unpivot(t *testing.T) {
+	tbl := salesTable(t)
+
+	g, _ := tbl.GroupBy("region", "product")
+	sums, _ := g.Aggregate(Sum("units", "units"))
+
+	pivot, err := sums.Pivot("region", "product", "units")
+	if err != nil {
+		t.Fatalf("Pivot: unexpected error %v", err)
+	}
+	expected := `region	apple	pear	plum
+north	15	3	
+south	4		7`
+	if pivot.String() != expected {
+		t.Fatalf("Pivot: expected\n%s\ngot\n%s", expected, pivot)
+	}
+
+	if _, err := tbl.Pivot("region", "product", "units"); !errors.Is(err, ErrDuplicateCell) {
+		t.Fatalf("Pivot: expected ErrDuplicateCell, got %v", err)
+	}
+
+	unpivot, err := pivot.Unpivot([]string{"region"}, "product", "units")
+	if err != nil {
+		t.Fatalf("Unpivot: unexpected error %v", err)
+	}
+	if typ, _ := unpivot.ColumnType("units"); typ != reflect.TypeOf(0) {
+		t.Fatalf("Unpivot: expected int values, got %v", typ)
+	}
+	back := unpivot.Where(func(r Row) bool { return r.Get("units") != nil })
+	back, _ = back.SortBy("product", "-region")
+	sums, _ = sums.SortBy("product", "-region")
+	if !reflect.DeepEqual(back.Rows(), sums.Rows()) {
+		t.Fatalf("Unpivot: expected %v, got %v", sums.Rows(), back.Rows())
+	}
+
+	mixed, _ := tbl.Unpivot([]string{"product"}, "name", "value")
+	if typ, _ := mixed.ColumnType("value"); typ.Kind() != reflect.Interface || mixed.Len() != 15 {
+		t.Fatalf("Unpivot: expected any values, got %v", typ)
+	}
+}
+
+func Test_ToSlice(t *testing.T) {
+	type report struct {
+		Region string  `table:"region"`
+		Total  float64 `table:"units"`
+		Skip   string  `table:"-"`
+		Other  string
+	}
+
+	g, _ := salesTable(t).GroupBy("region")
+	res, _ := g.Aggregate(Sum("units", "units"), Count("Other"))
+
+	_, err := ToSlice[report](res)
+	if !errors.Is(err, ErrColumnType) {
+		t.Fatalf("ToSlice: expected ErrColumnType for int to string, got %v", err)
+	}
+
+	res, _ = res.Select("region", "units")
+	out, err := ToSlice[report](res)
+	if err != nil {
+		t.Fatalf("ToSlice: unexpected error %v", err)
+	}
+	expected := []report{{Region: "north", Total: 18}, {Region: "south", Total: 11}}
+	if !reflect.DeepEqual(out, expected) {
+		t.Fatalf("ToSlice: expected %#v, got %#v", expected, out)
+	}
+
+	if _, err := ToSlice[int](res); !errors.Is(err, ErrColumnType) {
+		t.Fatalf("ToSlice: expected ErrColumnType for a non-struct, got %v", err)
+	}
+}
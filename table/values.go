@@ -0,0 +1,86 @@
+package table
+
+import "reflect"
+
+func isNumber(k reflect.Kind) bool {
+	return isInt(k) || isUint(k) || isFloat(k)
+}
+
+func isInt(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Int64
+}
+
+func isUint(k reflect.Kind) bool {
+	return k >= reflect.Uint && k <= reflect.Uintptr
+}
+
+func isFloat(k reflect.Kind) bool {
+	return k == reflect.Float32 || k == reflect.Float64
+}
+
+// isOrdered reports whether values of a column type can be compared.
+// Values of interface columns are checked when they are compared.
+func isOrdered(typ reflect.Type) bool {
+	k := typ.Kind()
+	return isNumber(k) || k == reflect.String || k == reflect.Bool || k == reflect.Interface
+}
+
+// compare returns -1, 0 or 1 comparing two cells. Missing values go first.
+// Numbers of different types are compared as numbers, other values of different kinds are ordered by kind.
+func compare(a, b any) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	ka, kb := va.Kind(), vb.Kind()
+
+	switch {
+	case isInt(ka) && isInt(kb):
+		return compareOrdered(va.Int(), vb.Int())
+	case isUint(ka) && isUint(kb):
+		return compareOrdered(va.Uint(), vb.Uint())
+	case isNumber(ka) && isNumber(kb):
+		return compareOrdered(toFloat(va), toFloat(vb))
+	case ka == reflect.String && kb == reflect.String:
+		return compareOrdered(va.String(), vb.String())
+	case ka == reflect.Bool && kb == reflect.Bool:
+		return compareOrdered(boolToInt(va.Bool()), boolToInt(vb.Bool()))
+	default:
+		return compareOrdered(ka, kb)
+	}
+}
+
+func compareOrdered[T int | int64 | uint64 | float64 | string | reflect.Kind](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func toFloat(v reflect.Value) float64 {
+	switch k := v.Kind(); {
+	case isInt(k):
+		return float64(v.Int())
+	case isUint(k):
+		return float64(v.Uint())
+	default:
+		return v.Float()
+	}
+}
@@ -0,0 +1,217 @@
+// Package tree builds trees out of flat slices of rows with parent IDs and walks them.
+package tree
+
+// Node is a value with its children.
+type Node[T any] struct {
+	Value    T
+	Children []*Node[T]
+}
+
+// Children returns children of a node. It can be used as a children function for Walk.
+func Children[T any](n *Node[T]) []*Node[T] {
+	return n.Children
+}
+
+// Order is an order in which Walk visits nodes.
+type Order int
+
+const (
+	// PreOrder visits a node before its children.
+	PreOrder Order = iota
+	// PostOrder visits a node after its children.
+	PostOrder
+	// BreadthFirst visits all the nodes of a level before going to the next one.
+	BreadthFirst
+)
+
+// FlatNode is an element of a slice returned by FlattenTree.
+type FlatNode[T any] struct {
+	Value T
+	// Depth is 0 for roots.
+	Depth int
+}
+
+// BuildTree links rows by their parent IDs and returns the roots. Children go in the order of the given slice.
+// Rows with a parent ID which is not in the slice (or equal to its own ID) are roots.
+// If several rows have the same ID, children are attached to the first one.
+// Rows linked into a cycle are not lost: the cycle is broken by making its first row a root.
+func BuildTree[T any, K comparable](in []T, id func(T) K, parent func(T) K) []*Node[T] {
+	if in == nil {
+		return nil
+	}
+
+	nodes := make([]*Node[T], len(in))
+	index := make(map[K]int, len(in))
+	for i, item := range in {
+		nodes[i] = &Node[T]{Value: item}
+		if _, ok := index[id(item)]; !ok {
+			index[id(item)] = i
+		}
+	}
+
+	// parents[i] is an index of the parent of in[i] or -1 for roots
+	parents := make([]int, len(in))
+	for i, item := range in {
+		p, ok := index[parent(item)]
+		if !ok || p == i {
+			p = -1
+		}
+		parents[i] = p
+	}
+
+	breakCycles(parents)
+
+	roots := make([]*Node[T], 0)
+	for i, p := range parents {
+		if p == -1 {
+			roots = append(roots, nodes[i])
+		} else {
+			nodes[p].Children = append(nodes[p].Children, nodes[i])
+		}
+	}
+
+	return roots
+}
+
+// breakCycles makes sure every chain of parents ends with a root.
+func breakCycles(parents []int) {
+	const (
+		unknown = iota
+		onPath
+		settled
+	)
+	state := make([]int, len(parents))
+
+	var path []int
+	for i := range parents {
+		path = path[:0]
+		cur := i
+		for cur != -1 && state[cur] == unknown {
+			state[cur] = onPath
+			path = append(path, cur)
+			cur = parents[cur]
+		}
+
+		if cur != -1 && state[cur] == onPath {
+			first := cur
+			for j := len(path) - 1; path[j] != cur; j-- {
+				if path[j] < first {
+					first = path[j]
+				}
+			}
+			parents[first] = -1
+		}
+
+		for _, k := range path {
+			state[k] = settled
+		}
+	}
+}
+
+// Walk visits every node of the trees with given roots in a given order, stopping when visit returns false.
+// Children of a node are returned by the children function, which should describe a tree: cycles are not detected.
+func Walk[T any](roots []T, children func(T) []T, order Order, visit func(node T, depth int) bool) {
+	switch order {
+	case PostOrder:
+		walkPostOrder(roots, children, 0, visit)
+	case BreadthFirst:
+		walkBreadthFirst(roots, children, visit)
+	default:
+		walkPreOrder(roots, children, 0, visit)
+	}
+}
+
+func walkPreOrder[T any](nodes []T, children func(T) []T, depth int, visit func(T, int) bool) bool {
+	for _, n := range nodes {
+		if !visit(n, depth) || !walkPreOrder(children(n), children, depth+1, visit) {
+			return false
+		}
+	}
+	return true
+}
+
+func walkPostOrder[T any](nodes []T, children func(T) []T, depth int, visit func(T, int) bool) bool {
+	for _, n := range nodes {
+		if !walkPostOrder(children(n), children, depth+1, visit) || !visit(n, depth) {
+			return false
+		}
+	}
+	return true
+}
+
+func walkBreadthFirst[T any](roots []T, children func(T) []T, visit func(T, int) bool) {
+	level := roots
+	for depth := 0; len(level) > 0; depth++ {
+		var next []T
+		for _, n := range level {
+			if !visit(n, depth) {
+				return
+			}
+			next = append(next, children(n)...)
+		}
+		level = next
+	}
+}
+
+// FlattenTree turns trees back into a slice in pre-order (a parent goes right before its children),
+// keeping the depth of every node.
+func FlattenTree[T any](roots []*Node[T]) []FlatNode[T] {
+	if roots == nil {
+		return nil
+	}
+
+	res := make([]FlatNode[T], 0, len(roots))
+	Walk(roots, Children[T], PreOrder, func(n *Node[T], depth int) bool {
+		res = append(res, FlatNode[T]{Value: n.Value, Depth: depth})
+		return true
+	})
+
+	return res
+}
+
+// MapTree creates new trees of the same shape with values converted by a given function.
+// The function gets a value, its depth and its parent node (nil for roots) of the original tree.
+func MapTree[T, Y any](roots []*Node[T], convert func(T, int, *Node[T]) Y) []*Node[Y] {
+	return mapNodes(roots, convert, 0, nil)
+}
+
+func mapNodes[T, Y any](nodes []*Node[T], convert func(T, int, *Node[T]) Y, depth int, parent *Node[T]) []*Node[Y] {
+	if nodes == nil {
+		return nil
+	}
+
+	res := make([]*Node[Y], 0, len(nodes))
+	for _, n := range nodes {
+		res = append(res, &Node[Y]{
+			Value:    convert(n.Value, depth, parent),
+			Children: mapNodes(n.Children, convert, depth+1, n),
+		})
+	}
+
+	return res
+}
+
+// FilterTree creates new trees keeping only the nodes a given function returns true for.
+// When a node is filtered out, its whole subtree goes with it, and the function is not called for its children.
+// The function gets a value, its depth and its parent node (nil for roots) of the original tree.
+func FilterTree[T any](roots []*Node[T], filter func(T, int, *Node[T]) bool) []*Node[T] {
+	return filterNodes(roots, filter, 0, nil)
+}
+
+func filterNodes[T any](nodes []*Node[T], filter func(T, int, *Node[T]) bool, depth int, parent *Node[T]) []*Node[T] {
+	if nodes == nil {
+		return nil
+	}
+
+	res := make([]*Node[T], 0, len(nodes))
+	for _, n := range nodes {
+		if filter(n.Value, depth, parent) {
+			res = append(res, &Node[T]{
+				Value:    n.Value,
+				Children: filterNodes(n.Children, filter, depth+1, n),
+			})
+		}
+	}
+
+	return res
+}
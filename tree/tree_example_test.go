@@ -0,0 +1,57 @@
+package tree
+
+import (
+	"fmt"
+	"strings"
+)
+
+type category struct {
+	ID       int
+	ParentID int
+	Name     string
+}
+
+func ExampleBuildTree() {
+	rows := []category{
+		{ID: 1, Name: "Electronics"},
+		{ID: 2, ParentID: 1, Name: "Phones"},
+		{ID: 3, ParentID: 1, Name: "Laptops"},
+		{ID: 4, ParentID: 2, Name: "Cases"},
+		{ID: 5, Name: "Books"},
+	}
+
+	roots := BuildTree(rows, func(c category) int { return c.ID }, func(c category) int { return c.ParentID })
+	names := MapTree(roots, func(c category, _ int, _ *Node[category]) string { return c.Name })
+
+	for _, n := range FlattenTree(names) {
+		fmt.Println(strings.Repeat("  ", n.Depth) + n.Value)
+	}
+
+	// Output:
+	// Electronics
+	//   Phones
+	//     Cases
+	//   Laptops
+	// Books
+}
+
+func ExampleWalk() {
+	roots := BuildTree([]category{
+		{ID: 1, Name: "src"},
+		{ID: 2, ParentID: 1, Name: "main.go"},
+		{ID: 3, ParentID: 1, Name: "util"},
+		{ID: 4, ParentID: 3, Name: "strings.go"},
+	}, func(c category) int { return c.ID }, func(c category) int { return c.ParentID })
+
+	// children are removed before their parents
+	Walk(roots, Children[category], PostOrder, func(n *Node[category], depth int) bool {
+		fmt.Println("rm", n.Value.Name)
+		return true
+	})
+
+	// Output:
+	// rm main.go
+	// rm strings.go
+	// rm util
+	// rm src
+}
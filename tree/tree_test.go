@@ -0,0 +1,230 @@
+package tree
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type row struct {
+	ID     int
+	Parent int
+	Name   string
+}
+
+func rowID(r row) int     { return r.ID }
+func rowParent(r row) int { return r.Parent }
+
+// render prints trees as "name(child child)" to compare them easily.
+func render[T any](nodes []*Node[T], name func(T) string) string {
+	parts := make([]string, 0, len(nodes))
+	for _, n := range nodes {
+		s := name(n.Value)
+		if n.Children != nil {
+			s += "(" + render(n.Children, name) + ")"
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, " ")
+}
+
+func rowName(r row) string { return r.Name }
+
+func Test_BuildTree(t *testing.T) {
+	tt := []struct {
+		name     string
+		in       []row
+		expected string
+	}{
+		{
+			name: "children go in the order of the slice",
+			in: []row{
+				{ID: 3, Parent: 1, Name: "c"},
+				{ID: 1, Parent: 0, Name: "a"},
+				{ID: 2, Parent: 1, Name: "b"},
+				{ID: 4, Parent: 2, Name: "d"},
+				{ID: 5, Parent: 0, Name: "e"},
+			},
+			expected: "a(c b(d)) e",
+		},
+		{
+			name: "unknown parent and self reference make roots",
+			in: []row{
+				{ID: 1, Parent: 42, Name: "a"},
+				{ID: 2, Parent: 2, Name: "b"},
+				{ID: 3, Parent: 2, Name: "c"},
+			},
+			expected: "a b(c)",
+		},
+		{
+			name: "duplicate IDs - children go to the first one",
+			in: []row{
+				{ID: 1, Parent: 0, Name: "a"},
+				{ID: 1, Parent: 0, Name: "b"},
+				{ID: 2, Parent: 1, Name: "c"},
+			},
+			expected: "a(c) b",
+		},
+		{
+			name: "cycle is broken at its first row",
+			in: []row{
+				{ID: 9, Parent: 3, Name: "x"},
+				{ID: 3, Parent: 2, Name: "c"},
+				{ID: 1, Parent: 3, Name: "a"},
+				{ID: 2, Parent: 1, Name: "b"},
+			},
+			expected: "c(x a(b))",
+		},
+		{
+			name:     "empty slice - no roots",
+			in:       []row{},
+			expected: "",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			res := render(BuildTree(tc.in, rowID, rowParent), rowName)
+
+			if res != tc.expected {
+				t.Fatalf(`BuildTree %s: expected
+				%q, got
+				%q`, tc.name, tc.expected, res)
+			}
+		})
+	}
+
+	if BuildTree[row, int](nil, rowID, rowParent) != nil {
+		t.Fatal("BuildTree: nil in - nil out")
+	}
+}
+
+func testTree() []*Node[string] {
+	rows := BuildTree([]row{
+		{ID: 1, Name: "a"},
+		{ID: 2, Parent: 1, Name: "b"},
+		{ID: 3, Parent: 2, Name: "c"},
+		{ID: 4, Parent: 1, Name: "d"},
+		{ID: 5, Name: "e"},
+		{ID: 6, Parent: 5, Name: "f"},
+	}, rowID, rowParent)
+
+	return MapTree(rows, func(r row, _ int, _ *Node[row]) string { return r.Name })
+}
+
+func Test_Walk(t *testing.T) {
+	tt := []struct {
+		name     string
+		order    Order
+		stopAt   string
+		expected string
+	}{
+		{name: "pre-order", order: PreOrder, expected: "a0 b1 c2 d1 e0 f1"},
+		{name: "post-order", order: PostOrder, expected: "c2 b1 d1 a0 f1 e0"},
+		{name: "breadth first", order: BreadthFirst, expected: "a0 e0 b1 d1 f1 c2"},
+		{name: "pre-order with stop", order: PreOrder, stopAt: "d", expected: "a0 b1 c2 d1"},
+		{name: "post-order with stop", order: PostOrder, stopAt: "b", expected: "c2 b1"},
+		{name: "breadth first with stop", order: BreadthFirst, stopAt: "d", expected: "a0 e0 b1 d1"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var visited []string
+			Walk(testTree(), Children[string], tc.order, func(n *Node[string], depth int) bool {
+				visited = append(visited, n.Value+string(rune('0'+depth)))
+				return n.Value != tc.stopAt
+			})
+
+			if res := strings.Join(visited, " "); res != tc.expected {
+				t.Fatalf("Walk %s: expected %q, got %q", tc.name, tc.expected, res)
+			}
+		})
+	}
+}
+
+func Test_Walk_ChildrenFunc(t *testing.T) {
+	// a binary heap laid out in a slice, walked by indexes
+	heap := []int{1, 3, 2, 7, 4}
+	children := func(i int) []int {
+		var res []int
+		for _, c := range []int{2*i + 1, 2*i + 2} {
+			if c < len(heap) {
+				res = append(res, c)
+			}
+		}
+		return res
+	}
+
+	var res []int
+	Walk([]int{0}, children, PostOrder, func(i int, _ int) bool {
+		res = append(res, heap[i])
+		return true
+	})
+
+	if expected := []int{7, 4, 3, 2, 1}; !reflect.DeepEqual(res, expected) {
+		t.Fatalf("Walk: expected %v, got %v", expected, res)
+	}
+}
+
+func Test_FlattenTree(t *testing.T) {
+	res := FlattenTree(testTree())
+	expected := []FlatNode[string]{
+		{"a", 0}, {"b", 1}, {"c", 2}, {"d", 1}, {"e", 0}, {"f", 1},
+	}
+	if !reflect.DeepEqual(res, expected) {
+		t.Fatalf(`FlattenTree: expected
+				%#v, got
+				%#v`, expected, res)
+	}
+
+	if FlattenTree[int](nil) != nil {
+		t.Fatal("FlattenTree: nil in - nil out")
+	}
+}
+
+func Test_MapTree(t *testing.T) {
+	in := testTree()
+	res := MapTree(in, func(v string, depth int, parent *Node[string]) string {
+		p := "-"
+		if parent != nil {
+			p = parent.Value
+		}
+		return strings.Repeat(">", depth) + v + "@" + p
+	})
+
+	expected := "a@-(>b@a(>>c@b) >d@a) e@-(>f@e)"
+	if res := render(res, func(s string) string { return s }); res != expected {
+		t.Fatalf("MapTree: expected %q, got %q", expected, res)
+	}
+	if in[0].Value != "a" {
+		t.Fatal("MapTree: original tree was changed")
+	}
+
+	if MapTree(nil, func(v string, _ int, _ *Node[string]) int { return 0 }) != nil {
+		t.Fatal("MapTree: nil in - nil out")
+	}
+}
+
+func Test_FilterTree(t *testing.T) {
+	in := testTree()
+
+	var called []string
+	res := FilterTree(in, func(v string, depth int, _ *Node[string]) bool {
+		called = append(called, v)
+		return v != "b" && v != "f"
+	})
+
+	if res := render(res, func(s string) string { return s }); res != "a(d) e()" {
+		t.Fatalf("FilterTree: unexpected %q", res)
+	}
+	if !reflect.DeepEqual(called, []string{"a", "b", "d", "e", "f"}) {
+		t.Fatalf("FilterTree: filter should not be called for removed subtrees, called for %v", called)
+	}
+	if len(in[0].Children) != 2 {
+		t.Fatal("FilterTree: original tree was changed")
+	}
+
+	if FilterTree(nil, func(string, int, *Node[string]) bool { return true }) != nil {
+		t.Fatal("FilterTree: nil in - nil out")
+	}
+}
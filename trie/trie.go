@@ -0,0 +1,236 @@
+// Package trie implements a prefix tree for string keys (a compressed radix tree),
+// so prefix queries are proportional to the size of the match, not to the number of keys.
+package trie
+
+import "sort"
+
+type node[V any] struct {
+	// label is the part of the key on the edge from the parent
+	label    string
+	value    V
+	hasValue bool
+	// children are sorted by the first byte of their labels, which are all different
+	children []*node[V]
+}
+
+// Trie is a map of string keys supporting prefix queries. The zero value is an empty trie ready to use.
+// It is not safe for concurrent use.
+type Trie[V any] struct {
+	root node[V]
+	size int
+}
+
+// New creates an empty trie.
+func New[V any]() *Trie[V] {
+	return &Trie[V]{}
+}
+
+// FromMap creates a trie from a map.
+func FromMap[V any](in map[string]V) *Trie[V] {
+	t := New[V]()
+	for k, v := range in {
+		t.Insert(k, v)
+	}
+	return t
+}
+
+// Len returns the number of keys.
+func (t *Trie[V]) Len() int {
+	return t.size
+}
+
+// Insert sets a value for a key. It returns true if the key was already in the trie.
+func (t *Trie[V]) Insert(key string, value V) bool {
+	n := &t.root
+	for {
+		if key == "" {
+			replaced := n.hasValue
+			n.value, n.hasValue = value, true
+			if !replaced {
+				t.size++
+			}
+			return replaced
+		}
+
+		i, child := n.child(key[0])
+		if child == nil {
+			n.insertChild(i, &node[V]{label: key, value: value, hasValue: true})
+			t.size++
+			return false
+		}
+
+		l := commonPrefix(child.label, key)
+		if l < len(child.label) {
+			// split the edge
+			middle := &node[V]{label: child.label[:l], children: []*node[V]{child}}
+			child.label = child.label[l:]
+			n.children[i] = middle
+			child = middle
+		}
+		n, key = child, key[l:]
+	}
+}
+
+// Get returns a value by its key and whether it was found.
+func (t *Trie[V]) Get(key string) (V, bool) {
+	n := t.find(key)
+	if n == nil || !n.hasValue {
+		var zero V
+		return zero, false
+	}
+	return n.value, true
+}
+
+// Delete removes a key. It returns false if there was no such key.
+func (t *Trie[V]) Delete(key string) bool {
+	if !t.root.delete(key) {
+		return false
+	}
+	t.size--
+	return true
+}
+
+// WithPrefix calls fn for all the keys starting with the prefix in lexicographic order, until fn returns false.
+func (t *Trie[V]) WithPrefix(prefix string, fn func(key string, value V) bool) {
+	n, path := &t.root, ""
+	for prefix != "" {
+		_, child := n.child(prefix[0])
+		if child == nil {
+			return
+		}
+
+		l := commonPrefix(child.label, prefix)
+		switch {
+		case l == len(prefix):
+			// the prefix ends in the middle (or at the end) of the edge
+			child.walk(path+child.label, fn)
+			return
+		case l < len(child.label):
+			return
+		}
+		n, path, prefix = child, path+child.label, prefix[l:]
+	}
+
+	n.walk(path, fn)
+}
+
+// LongestPrefixMatch finds the longest key which is a prefix of s. If there is no such key, ok is false.
+func (t *Trie[V]) LongestPrefixMatch(s string) (key string, value V, ok bool) {
+	n, path := &t.root, ""
+	for {
+		if n.hasValue {
+			key, value, ok = path, n.value, true
+		}
+		if len(path) == len(s) {
+			return key, value, ok
+		}
+
+		_, child := n.child(s[len(path)])
+		if child == nil || commonPrefix(child.label, s[len(path):]) < len(child.label) {
+			return key, value, ok
+		}
+		n, path = child, s[:len(path)+len(child.label)]
+	}
+}
+
+// Map returns all the keys and values as a map. An empty trie gives nil.
+func (t *Trie[V]) Map() map[string]V {
+	if t.size == 0 {
+		return nil
+	}
+
+	out := make(map[string]V, t.size)
+	t.root.walk("", func(k string, v V) bool {
+		out[k] = v
+		return true
+	})
+	return out
+}
+
+// Keys returns all the keys in lexicographic order.
+func (t *Trie[V]) Keys() []string {
+	out := make([]string, 0, t.size)
+	t.root.walk("", func(k string, _ V) bool {
+		out = append(out, k)
+		return true
+	})
+	return out
+}
+
+func (t *Trie[V]) find(key string) *node[V] {
+	n := &t.root
+	for key != "" {
+		_, child := n.child(key[0])
+		if child == nil || commonPrefix(child.label, key) < len(child.label) {
+			return nil
+		}
+		n, key = child, key[len(child.label):]
+	}
+	return n
+}
+
+// child returns the child with a label starting with b, or the position to insert such a child.
+func (n *node[V]) child(b byte) (int, *node[V]) {
+	i := sort.Search(len(n.children), func(i int) bool { return n.children[i].label[0] >= b })
+	if i < len(n.children) && n.children[i].label[0] == b {
+		return i, n.children[i]
+	}
+	return i, nil
+}
+
+func (n *node[V]) insertChild(i int, child *node[V]) {
+	n.children = append(n.children, nil)
+	copy(n.children[i+1:], n.children[i:])
+	n.children[i] = child
+}
+
+// delete removes the key below the node and compacts the edges on the way back.
+func (n *node[V]) delete(key string) bool {
+	if key == "" {
+		if !n.hasValue {
+			return false
+		}
+		var zero V
+		n.value, n.hasValue = zero, false
+		return true
+	}
+
+	i, child := n.child(key[0])
+	if child == nil || commonPrefix(child.label, key) < len(child.label) {
+		return false
+	}
+	if !child.delete(key[len(child.label):]) {
+		return false
+	}
+
+	switch {
+	case !child.hasValue && len(child.children) == 0:
+		n.children = append(n.children[:i], n.children[i+1:]...)
+	case !child.hasValue && len(child.children) == 1:
+		// merge the edges
+		grandchild := child.children[0]
+		grandchild.label = child.label + grandchild.label
+		n.children[i] = grandchild
+	}
+	return true
+}
+
+func (n *node[V]) walk(path string, fn func(string, V) bool) bool {
+	if n.hasValue && !fn(path, n.value) {
+		return false
+	}
+	for _, child := range n.children {
+		if !child.walk(path+child.label, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+func commonPrefix(a, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
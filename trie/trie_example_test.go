@@ -0,0 +1,38 @@
+package trie
+
+import "fmt"
+
+func ExampleTrie_LongestPrefixMatch() {
+	routes := FromMap(map[string]string{
+		"/":          "index",
+		"/api/":      "api",
+		"/api/users": "users",
+	})
+
+	for _, path := range []string{"/api/users/42", "/api/orders", "/about"} {
+		prefix, handler, _ := routes.LongestPrefixMatch(path)
+		fmt.Println(path, "->", prefix, handler)
+	}
+
+	// Output:
+	// /api/users/42 -> /api/users users
+	// /api/orders -> /api/ api
+	// /about -> / index
+}
+
+func ExampleTrie_WithPrefix() {
+	flags := FromMap(map[string]bool{
+		"billing.new_invoices": true,
+		"billing.refunds":      false,
+		"search.fuzzy":         true,
+	})
+
+	flags.WithPrefix("billing.", func(key string, enabled bool) bool {
+		fmt.Println(key, enabled)
+		return true
+	})
+
+	// Output:
+	// billing.new_invoices true
+	// billing.refunds false
+}
@@ -0,0 +1,130 @@
+package trie
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func randomKey(r *rand.Rand) string {
+	b := make([]byte, r.Intn(6))
+	for i := range b {
+		b[i] = "abc"[r.Intn(3)]
+	}
+	return string(b)
+}
+
+func Test_Trie(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	tr := New[int]()
+	expected := map[string]int{}
+
+	for step := 0; step < 5000; step++ {
+		k := randomKey(r)
+		_, exists := expected[k]
+		if r.Intn(3) == 0 {
+			if tr.Delete(k) != exists {
+				t.Fatalf("Delete(%q): expected %v", k, exists)
+			}
+			delete(expected, k)
+		} else {
+			if tr.Insert(k, step) != exists {
+				t.Fatalf("Insert(%q): expected %v", k, exists)
+			}
+			expected[k] = step
+		}
+
+		if tr.Len() != len(expected) {
+			t.Fatalf("Len: expected %d, got %d", len(expected), tr.Len())
+		}
+
+		q := randomKey(r)
+		if v, ok := tr.Get(q); ok != hasKey(expected, q) || v != expected[q] {
+			t.Fatalf("Get(%q): unexpected %d, %v", q, v, ok)
+		}
+
+		var withPrefix []string
+		tr.WithPrefix(q, func(k string, v int) bool {
+			if expected[k] != v {
+				t.Fatalf("WithPrefix(%q): unexpected value %d for %q", q, v, k)
+			}
+			withPrefix = append(withPrefix, k)
+			return true
+		})
+		if expectedKeys := prefixed(expected, q); !reflect.DeepEqual(withPrefix, expectedKeys) {
+			t.Fatalf("WithPrefix(%q): expected %v, got %v", q, expectedKeys, withPrefix)
+		}
+
+		k, _, ok := tr.LongestPrefixMatch(q)
+		if expectedKey, expectedOk := longestPrefix(expected, q); ok != expectedOk || k != expectedKey {
+			t.Fatalf("LongestPrefixMatch(%q): expected %q, got %q", q, expectedKey, k)
+		}
+	}
+
+	if res := tr.Map(); !reflect.DeepEqual(res, expected) && len(expected) > 0 {
+		t.Fatalf("Map: expected %v, got %v", expected, res)
+	}
+}
+
+func hasKey(m map[string]int, k string) bool {
+	_, ok := m[k]
+	return ok
+}
+
+func prefixed(m map[string]int, prefix string) []string {
+	var out []string
+	for k := range m {
+		if strings.HasPrefix(k, prefix) {
+			out = append(out, k)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func longestPrefix(m map[string]int, s string) (string, bool) {
+	for l := len(s); l >= 0; l-- {
+		if _, ok := m[s[:l]]; ok {
+			return s[:l], true
+		}
+	}
+	return "", false
+}
+
+func Test_Trie_WithPrefixStops(t *testing.T) {
+	tr := FromMap(map[string]bool{"/api/users": true, "/api/orders": true, "/api/items": true, "/health": true})
+
+	var res []string
+	tr.WithPrefix("/api/", func(k string, _ bool) bool {
+		res = append(res, k)
+		return len(res) < 2
+	})
+
+	if expected := []string{"/api/items", "/api/orders"}; !reflect.DeepEqual(res, expected) {
+		t.Fatalf("WithPrefix: expected %v, got %v", expected, res)
+	}
+	if keys := tr.Keys(); !reflect.DeepEqual(keys, []string{"/api/items", "/api/orders", "/api/users", "/health"}) {
+		t.Fatalf("Keys: unexpected %v", keys)
+	}
+}
+
+func Test_Trie_Empty(t *testing.T) {
+	var tr Trie[int]
+
+	if _, ok := tr.Get(""); ok {
+		t.Fatal("Get: expected no keys")
+	}
+	if _, _, ok := tr.LongestPrefixMatch("abc"); ok {
+		t.Fatal("LongestPrefixMatch: expected no keys")
+	}
+	if tr.Map() != nil {
+		t.Fatal("Map: expected nil")
+	}
+
+	tr.Insert("", 1)
+	if v, ok := tr.Get(""); !ok || v != 1 {
+		t.Fatal("Get: expected an empty key to be found")
+	}
+}